@@ -1,6 +1,8 @@
 package actor
 
 import (
+	"context"
+	"log/slog"
 	"reflect"
 	"time"
 
@@ -17,6 +19,11 @@ type Actor interface{}
 
 type spawnActorSettings struct {
 	deferredSpawn bool
+	journal       Journal
+	snapshotEvery uint64
+	logger        *slog.Logger
+	ctx           context.Context
+	callTimeout   time.Duration
 }
 
 // SpawnActorOption is a function that sets up an option during the SpawnActor/FinishSpawningActor functions
@@ -39,16 +46,13 @@ func SpawnActor(typ reflect.Type, opts ...SpawnActorOption) (Actor, error) {
 		}
 	}
 
-	a, ok := reflect.New(typ).Interface().(Actor)
-	if !ok {
-		return nil, errors.Wrapf(ErrActorSpawn, "unexpected type %v", typ)
-	}
-
-	if err := PostSpawnInitialize(a); err != nil {
+	a, err := allocateActor(typ, nil, s)
+	if err != nil {
 		return nil, err
 	}
 
 	if s.deferredSpawn {
+		s.log().Debug("actor spawn deferred", slog.String("actor_type", reflect.TypeOf(a).String()))
 		return a, nil
 	}
 
@@ -59,7 +63,30 @@ func SpawnActor(typ reflect.Type, opts ...SpawnActorOption) (Actor, error) {
 	return a, nil
 }
 
+// allocateActor creates an actor of the type provided and calls PostSpawnInitialize() on it
+func allocateActor(typ reflect.Type, pool *ActorPool, s spawnActorSettings) (Actor, error) {
+	a, ok := reflect.New(typ).Interface().(Actor)
+	if !ok {
+		return nil, errors.Wrapf(ErrActorSpawn, "unexpected type %v", typ)
+	}
+
+	l := &lifecycle{
+		pool: pool,
+	}
+	l.state.Store(int32(StateAllocated))
+	lifecycles.Store(a, l)
+
+	if err := postSpawnInitialize(s.context(), a, s.callTimeout); err != nil {
+		untrackLifecycle(a)
+		return nil, err
+	}
+	l.state.Store(int32(StatePostSpawnInitialized))
+
+	return a, nil
+}
+
 // FinishSpawningActor finishes the spawning process for actors created with DeferredSpawnActor enabled
+// it may only be called once per actor - further calls return a *LifecycleError
 func FinishSpawningActor(a Actor, opts ...SpawnActorOption) error {
 	s := spawnActorSettings{}
 	for _, opt := range opts {
@@ -68,35 +95,58 @@ func FinishSpawningActor(a Actor, opts ...SpawnActorOption) error {
 		}
 	}
 
-	if err := ExecuteConstruction(a); err != nil {
+	l := trackLifecycle(a, StatePostSpawnInitialized)
+	if err := l.transition("FinishSpawningActor", StateConstructing, StatePostSpawnInitialized); err != nil {
 		return err
 	}
 
-	if err := OnConstruction(a); err != nil {
+	if err := constructActor(s.context(), a, &s); err != nil {
+		// it's in an unknown state, so stop tracking it
+		untrackLifecycle(a)
 		return err
 	}
 
-	if err := PostActorConstruction(a); err != nil {
+	l.state.Store(int32(StateSpawned))
+	notifyLifecycle(LifecycleEvent{
+		Kind:  EventSpawned,
+		Actor: a,
+	})
+
+	s.log().Debug("actor spawned", slog.String("actor_type", reflect.TypeOf(a).String()))
+	return nil
+}
+
+// constructActor runs the construction stages of spawning, from ExecuteConstruction() through OnActorSpawned()
+func constructActor(ctx context.Context, a Actor, s *spawnActorSettings) error {
+	if err := executeConstruction(ctx, a, s.callTimeout); err != nil {
 		return err
 	}
 
-	if err := PreInitializeComponents(a); err != nil {
+	if err := recoverPersistentActor(a, s); err != nil {
 		return err
 	}
 
-	if err := InitializeComponents(a); err != nil {
+	if err := onConstruction(ctx, a, s.callTimeout); err != nil {
 		return err
 	}
 
-	if err := PostInitializeComponents(a); err != nil {
+	if err := postActorConstruction(ctx, a, s.callTimeout); err != nil {
 		return err
 	}
 
-	if err := OnActorSpawned(a); err != nil {
+	if err := preInitializeComponents(ctx, a, s.callTimeout); err != nil {
 		return err
 	}
 
-	return nil
+	if err := initializeComponents(ctx, a, s.callTimeout); err != nil {
+		return err
+	}
+
+	if err := postInitializeComponents(ctx, a, s.callTimeout); err != nil {
+		return err
+	}
+
+	return onActorSpawned(ctx, a, s.callTimeout)
 }
 
 // PostSpawnInitializeIntf is for actors that want to have PostSpawnInitialize() called right before PostActorCreated() is called
@@ -134,6 +184,12 @@ type PostInitializeComponentsIntf interface {
 	PostInitializeComponents() error
 }
 
+// ResetIntf is for pooled actors that want to have Reset() called instead of FinishDestroy() when they are returned to their pool
+// see: ActorPool
+type ResetIntf interface {
+	Reset() error
+}
+
 // OnActorSpawnedIntf is for actors that want to have OnActorSpawned() called right before BeginPlay() is called
 type OnActorSpawnedIntf interface {
 	OnActorSpawned() error
@@ -144,6 +200,15 @@ type BeginPlayIntf interface {
 	BeginPlay() error
 }
 
+// AsyncBeginPlayIntf is for actors that need to wait on something (e.g.: loading assets or opening connections) before
+// they're ready to play - it's called instead of BeginPlay(), and the channel returned receives nil once the actor is
+// ready or the error it failed with. The actor is held out of its tick group (in the Loading state) until then.
+// the context is cancelled once the actor has finished loading, when it times out (see: LoadTimeout()), or when it's
+// removed from the manager before then
+type AsyncBeginPlayIntf interface {
+	BeginPlayAsync(ctx context.Context) <-chan error
+}
+
 // WantTickIntf is for actors that want to announce that they can't Tick() sometimes
 type WantTickIntf interface {
 	WantTick() (bool, error)
@@ -154,6 +219,38 @@ type TickIntf interface {
 	Tick(deltaTime time.Duration) error
 }
 
+// TickWithContextIntf is for actors that want more than the deltaTime passed to Tick() - it's called instead of Tick()
+// when an actor implements both
+type TickWithContextIntf interface {
+	TickWithContext(tc TickContext) error
+}
+
+// SignificanceIntf is for actors that want the manager to move them between tick rates based on how significant they are
+// see: Manager.SetSignificancePolicy()
+type SignificanceIntf interface {
+	Significance() (float64, error)
+}
+
+// ReceiveIntf is for actors that want to have Receive() called when a message is sent to them via Tell()
+type ReceiveIntf interface {
+	Receive(msg Message) error
+}
+
+// RespondIntf is for actors that want to reply to messages sent to them via Ask()
+type RespondIntf interface {
+	Respond(msg Message) (Message, error)
+}
+
+// NetUpdateFrequencyIntf is for replicated actors that want to limit how many times per second their changes are sent to clients
+type NetUpdateFrequencyIntf interface {
+	NetUpdateFrequency() float64
+}
+
+// OnReplicatedIntf is for proxy actors that want to have OnReplicated() called after replicated fields are updated
+type OnReplicatedIntf interface {
+	OnReplicated(fields []string) error
+}
+
 // EndPlayIntf is for actors that want to have EndPlay() called after the Tick() loop ends and before BeginDestroy() is called
 type EndPlayIntf interface {
 	EndPlay(endPlayReason error) error
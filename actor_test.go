@@ -1,13 +1,46 @@
 package actor_test
 
 import (
+	"context"
 	"math/rand"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/heucuva/actor"
 )
 
+// newTestManager creates a manager that is stopped once the test is done, for tests that set it up before it ticks
+func newTestManager(t testing.TB) *actor.Manager {
+	t.Helper()
+
+	m := actor.NewManager()
+	t.Cleanup(m.Stop)
+	return m
+}
+
+// startTestManager creates a manager that ticks until the test is done
+func startTestManager(t testing.TB) *actor.Manager {
+	t.Helper()
+
+	m := newTestManager(t)
+	m.StartTicking(context.Background())
+	return m
+}
+
+// startClockTestManager creates a manager that ticks on a fake clock, starting at the time provided, until the test is done
+func startClockTestManager(t testing.TB, start time.Time) (*actor.Manager, *actor.FakeClock) {
+	t.Helper()
+
+	clock := actor.NewFakeClock(start)
+	m := newTestManager(t)
+	if err := m.SetClock(clock); err != nil {
+		t.Fatal(err)
+	}
+	m.StartTicking(context.Background())
+	return m, clock
+}
+
 type spawnActorTest struct {
 	counter int
 
@@ -0,0 +1,153 @@
+package actor_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type batchActorTest struct {
+	id    int
+	order *[]string
+}
+
+func (a *batchActorTest) BeginPlay() error {
+	*a.order = append(*a.order, fmt.Sprintf("begin %d", a.id))
+	return nil
+}
+
+func (a *batchActorTest) EndPlay(endPlayReason error) error {
+	*a.order = append(*a.order, fmt.Sprintf("end %d", a.id))
+	return nil
+}
+
+func TestBatchAddRemoveActors(t *testing.T) {
+	m := startTestManager(t)
+
+	const numActors = 1000
+
+	var order []string
+	actors := make([]actor.Actor, numActors)
+	specs := make([]actor.ActorSpec, numActors)
+	for i := range actors {
+		actors[i] = &batchActorTest{id: i, order: &order}
+		specs[i] = actor.ActorSpec{
+			Actor:   actors[i],
+			Options: []actor.Option{actor.TickInterval(time.Duration(i%10+1) * time.Hour)},
+		}
+	}
+	// a name clash within the batch fails only the later actor
+	specs[1].Options = append(specs[1].Options, actor.Name("dupe"))
+	specs[2].Options = append(specs[2].Options, actor.Name("dupe"))
+
+	err := m.AddActors(specs...)
+	var berr *actor.BatchError
+	if !errors.As(err, &berr) || len(berr.Errors) != numActors || !errors.Is(berr.Errors[2], actor.ErrActorNameTaken) {
+		t.Fatalf("expected a BatchError failing actor 2 with ErrActorNameTaken, got %v", err)
+	}
+	if len(berr.Unwrap()) != 1 {
+		t.Fatalf("expected only 1 failure, got %v", err)
+	}
+
+	stats := m.Stats()
+	if len(stats.Actors) != numActors-1 || len(stats.TickGroups) != 10 {
+		t.Fatalf("expected %d actors in 10 tick groups, got %d in %d", numActors-1, len(stats.Actors), len(stats.TickGroups))
+	}
+
+	for i, entry := range order {
+		id := i
+		if i >= 2 {
+			id++ // actor 2 was never begun
+		}
+		if expected := fmt.Sprintf("begin %d", id); entry != expected {
+			t.Fatalf("expected %q at %d, got %q", expected, i, entry)
+		}
+	}
+
+	order = nil
+	if err := m.RemoveActors(actors[:3], nil); !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound for the actor never added, got %v", err)
+	}
+	if expected := []string{"end 0", "end 1"}; fmt.Sprint(order) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+
+	if err := m.RemoveActors(actors[3:], nil); err != nil {
+		t.Fatal(err)
+	}
+	if stats := m.Stats(); len(stats.Actors) != 0 || len(stats.TickGroups) != 0 {
+		t.Fatalf("expected no actors or tick groups left, got %+v", stats)
+	}
+}
+
+type batchStopperTest struct {
+	m     *actor.Manager
+	ended bool
+}
+
+func (a *batchStopperTest) BeginPlay() error {
+	go a.m.Stop()
+	// wait for Stop() to get going
+	for !errors.Is(a.m.Tell(a, nil), actor.ErrManagerStopped) {
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+func (a *batchStopperTest) EndPlay(endPlayReason error) error {
+	a.ended = true
+	return nil
+}
+
+func TestAddActorsWhileStopping(t *testing.T) {
+	m := startTestManager(t)
+
+	a := &batchStopperTest{m: m}
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); !errors.Is(err, actor.ErrManagerStopped) {
+		t.Fatalf("expected ErrManagerStopped, got %v", err)
+	}
+	if !a.ended {
+		t.Fatal("expected the actor to end play")
+	}
+	if state := actor.State(a); state != actor.StateUnknown {
+		t.Fatalf("expected the actor to no longer be tracked, got %v", state)
+	}
+}
+
+type batchNameThiefTest struct {
+	m     *actor.Manager
+	thief actor.Actor
+	ended error
+}
+
+func (a *batchNameThiefTest) BeginPlay() error {
+	// claim the name while the first actor is still beginning play
+	return a.m.AddActor(a.thief, actor.TickInterval(time.Hour), actor.Name("contested"))
+}
+
+func (a *batchNameThiefTest) EndPlay(endPlayReason error) error {
+	a.ended = endPlayReason
+	return nil
+}
+
+func TestAddActorsNameTakenWhileBeginningPlay(t *testing.T) {
+	m := startTestManager(t)
+
+	thief := &batchActorTest{order: new([]string)}
+	a := &batchNameThiefTest{m: m, thief: thief}
+	if err := m.AddActor(a, actor.TickInterval(time.Hour), actor.Name("contested")); !errors.Is(err, actor.ErrActorNameTaken) {
+		t.Fatalf("expected ErrActorNameTaken, got %v", err)
+	}
+	if !errors.Is(a.ended, actor.ErrActorNameTaken) {
+		t.Fatalf("expected the actor to end play with ErrActorNameTaken, got %v", a.ended)
+	}
+	if state := actor.State(a); state != actor.StateUnknown {
+		t.Fatalf("expected the actor to no longer be tracked, got %v", state)
+	}
+	if found, err := m.ActorByName("contested"); err != nil || found != thief {
+		t.Fatalf("expected the name to belong to the other actor, got %v (%v)", found, err)
+	}
+}
@@ -0,0 +1,102 @@
+package actor
+
+import (
+	"sort"
+	"time"
+)
+
+// TickBudget limits how long a tick group may spend ticking its actors on each tick
+type TickBudget struct {
+	// Budget is the amount of time the tick group's actors may collectively take to tick
+	Budget time.Duration
+	// DeferRemaining defers the actors that have not yet ticked once the budget is exceeded to the group's next tick
+	// the deferred actors are the first to tick next time, so the same actors don't always starve
+	DeferRemaining bool
+	// OnOverrun is called (from the manager's tick goroutine) whenever the tick group exceeds its budget
+	OnOverrun func(TickOverrun)
+}
+
+// TickOverrun describes a tick group exceeding its TickBudget
+// Elapsed includes the time spent earlier in the same cycle of the interval by the groups for its other phases
+type TickOverrun struct {
+	Interval time.Duration
+	Budget   time.Duration
+	Elapsed  time.Duration
+	Ticked   int
+	Deferred int
+}
+
+// SetTickBudget sets the budget for the tick group of the interval provided (zero being the Every-Frame group)
+// actors ticking at different phases of the interval share the one budget, and calendar groups can't be budgeted
+// a budget of zero removes it
+func (m *Manager) SetTickBudget(interval time.Duration, b TickBudget) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if b.Budget <= 0 {
+		delete(m.tickBudgets, interval)
+		return
+	}
+	m.tickBudgets[interval] = &tickBudgetState{TickBudget: b}
+}
+
+// tickBudgetState is a TickBudget along with how much of it has been spent during the current cycle of its interval,
+// as the tick groups for each phase of the interval tick at different times
+type tickBudgetState struct {
+	TickBudget
+	cycle int64
+	spent time.Duration
+}
+
+// begin returns how much of the budget has already been spent by the interval's other phases during the cycle
+// provided (the number of intervals since the manager's epoch, or the frame for the Every-Frame group)
+func (b *tickBudgetState) begin(cycle int64) time.Duration {
+	if cycle != b.cycle {
+		b.cycle = cycle
+		b.spent = 0
+	}
+	return b.spent
+}
+
+type tickStatus int
+
+const (
+	tickStatusTicked = tickStatus(iota)
+	tickStatusSkipped
+	tickStatusDeferred
+)
+
+// orderForBudget sorts the actors by ID, then rotates them so that the actor with the cursor ID (or the next one after it) comes first
+func orderForBudget(actors []Actor, infos []actorMgrInfo, cursor uint64) {
+	sort.Sort(actorsByID{actors: actors, infos: infos})
+
+	start := sort.Search(len(infos), func(i int) bool {
+		return infos[i].id >= cursor
+	})
+	if start == 0 || start == len(infos) {
+		return
+	}
+
+	rotated := append(append(make([]Actor, 0, len(actors)), actors[start:]...), actors[:start]...)
+	rotatedInfos := append(append(make([]actorMgrInfo, 0, len(infos)), infos[start:]...), infos[:start]...)
+	copy(actors, rotated)
+	copy(infos, rotatedInfos)
+}
+
+type actorsByID struct {
+	actors []Actor
+	infos  []actorMgrInfo
+}
+
+func (s actorsByID) Len() int {
+	return len(s.actors)
+}
+
+func (s actorsByID) Less(i, j int) bool {
+	return s.infos[i].id < s.infos[j].id
+}
+
+func (s actorsByID) Swap(i, j int) {
+	s.actors[i], s.actors[j] = s.actors[j], s.actors[i]
+	s.infos[i], s.infos[j] = s.infos[j], s.infos[i]
+}
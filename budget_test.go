@@ -0,0 +1,122 @@
+package actor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type budgetActorTest struct {
+	ticks int
+}
+
+func (a *budgetActorTest) Tick(deltaTime time.Duration) error {
+	a.ticks++
+	time.Sleep(2 * time.Millisecond)
+	return nil
+}
+
+func TestTickBudgetDefersRoundRobin(t *testing.T) {
+	const (
+		interval  = 5 * time.Millisecond
+		numActors = 4
+	)
+
+	m := actor.NewManager()
+	var overruns int32
+	m.SetTickBudget(interval, actor.TickBudget{
+		Budget:         time.Millisecond,
+		DeferRemaining: true,
+		OnOverrun: func(o actor.TickOverrun) {
+			if o.Ticked != 1 || o.Deferred != numActors-1 {
+				t.Errorf("expected 1 ticked and %d deferred, got %+v", numActors-1, o)
+			}
+			atomic.AddInt32(&overruns, 1)
+		},
+	})
+	m.StartTicking(context.Background())
+	defer m.Stop()
+
+	for i := 0; i < numActors; i++ {
+		if err := m.AddActor(&budgetActorTest{}, actor.TickInterval(interval)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stats actor.ManagerStats
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats = m.Stats()
+		if len(stats.TickGroups) == 1 && stats.TickGroups[0].TickCount >= numActors*3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tick group did not tick enough - got %+v", stats)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// only one actor fits in the budget each tick, so each should get its turn
+	for _, as := range stats.Actors {
+		if as.TickCount < 2 {
+			t.Fatalf("actor %d starved - got %+v", as.ID, as)
+		}
+		if as.DeferredCount == 0 {
+			t.Fatalf("actor %d never deferred - got %+v", as.ID, as)
+		}
+	}
+
+	if stats.TickGroups[0].Overruns == 0 || atomic.LoadInt32(&overruns) == 0 {
+		t.Fatal("overruns not recorded")
+	}
+}
+
+func TestTickBudgetSharedByPhases(t *testing.T) {
+	const (
+		interval = 50 * time.Millisecond
+		phase    = 25 * time.Millisecond
+	)
+
+	m := actor.NewManager()
+	m.SetTickBudget(interval, actor.TickBudget{
+		Budget:         3 * time.Millisecond,
+		DeferRemaining: true,
+	})
+	m.StartTicking(context.Background())
+	defer m.Stop()
+
+	// each phase's actors fit in the budget on their own, but not together
+	for _, offset := range []time.Duration{0, phase} {
+		for i := 0; i < 2; i++ {
+			if err := m.AddActor(&budgetActorTest{}, actor.TickInterval(interval), actor.TickPhase(offset)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	var stats actor.ManagerStats
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats = m.Stats()
+		ticked := len(stats.TickGroups) == 2
+		for _, tgs := range stats.TickGroups {
+			ticked = ticked && tgs.TickCount >= 3
+		}
+		if ticked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tick groups did not tick enough - got %+v", stats)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, tgs := range stats.TickGroups {
+		if tgs.Phase == phase && (tgs.DeferredCount == 0 || tgs.Overruns == 0) {
+			t.Fatalf("expected the later phase to be held to what's left of the budget, got %+v", tgs)
+		}
+	}
+}
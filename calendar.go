@@ -0,0 +1,340 @@
+package actor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTickSchedule is for when a cron spec can't be parsed, or TickAt() is given no times
+var ErrInvalidTickSchedule = errors.New("invalid tick schedule")
+
+// tickCalendar is a wall-clock schedule that a tick group ticks on, instead of a fixed interval
+type tickCalendar interface {
+	// next returns the first time in the schedule after the time provided, if there is one
+	next(after time.Time) (time.Time, bool)
+	// String returns the schedule in a form that identifies it (and that it may be parsed back from)
+	String() string
+}
+
+// TickCron ticks the actor on a cron schedule, rather than on a fixed interval
+// the spec has six fields - second, minute, hour, day of month, month and day of week - e.g.: "0 */5 * * * *" ticks
+// every 5 minutes on the minute. Five field specs (without seconds) and the @yearly, @monthly, @weekly, @daily and
+// @hourly shorthands are accepted too. Times are in the local time zone, unless the spec is prefixed with one - e.g.:
+// "CRON_TZ=America/New_York 0 0 9 * * MON-FRI" (see: TickCronIn())
+func TickCron(spec string) Option {
+	return func(s *actorSettings) error {
+		c, err := parseCron(spec, time.Local)
+		if err != nil {
+			return err
+		}
+
+		s.setCalendar(c)
+		return nil
+	}
+}
+
+// TickCronIn ticks the actor on a cron schedule (see: TickCron()) in the time zone provided
+// the time zone is saved by name in snapshots, so it must be one time.LoadLocation() can load - not a time.FixedZone()
+func TickCronIn(spec string, loc *time.Location) Option {
+	return func(s *actorSettings) error {
+		if loc != time.Local {
+			if _, err := time.LoadLocation(loc.String()); err != nil {
+				return errors.Wrapf(ErrInvalidTickSchedule, "time zone %q can't be loaded by name", loc)
+			}
+		}
+
+		c, err := parseCron(spec, loc)
+		if err != nil {
+			return err
+		}
+
+		s.setCalendar(c)
+		return nil
+	}
+}
+
+// TickAt ticks the actor once at each of the times provided, rather than on a fixed interval
+// times that have already passed when the actor is added are skipped, and the actor stops ticking after the last one
+func TickAt(times ...time.Time) Option {
+	return func(s *actorSettings) error {
+		if len(times) == 0 {
+			return errors.Wrap(ErrInvalidTickSchedule, "no times")
+		}
+
+		s.setCalendar(newAtSchedule(times))
+		return nil
+	}
+}
+
+func (s *actorSettings) setCalendar(c tickCalendar) {
+	s.tickInterval = 0
+	s.calendar = c
+}
+
+// calendarOption rebuilds the Option that would produce the calendar provided
+func calendarOption(c tickCalendar) Option {
+	switch t := c.(type) {
+	case *atSchedule:
+		return TickAt(t.times...)
+	default:
+		return TickCron(c.String())
+	}
+}
+
+// parseTickSchedule parses a schedule in the form returned by tickCalendar.String()
+func parseTickSchedule(spec string) (tickCalendar, error) {
+	if strings.HasPrefix(spec, atSchedulePrefix) {
+		var times []time.Time
+		for _, f := range strings.Split(strings.TrimPrefix(spec, atSchedulePrefix), ",") {
+			t, err := time.Parse(time.RFC3339Nano, f)
+			if err != nil {
+				return nil, errors.Wrapf(ErrInvalidTickSchedule, "%q: %v", spec, err)
+			}
+			times = append(times, t)
+		}
+		return newAtSchedule(times), nil
+	}
+
+	return parseCron(spec, time.Local)
+}
+
+const atSchedulePrefix = "@at "
+
+type atSchedule struct {
+	times []time.Time
+}
+
+func newAtSchedule(times []time.Time) *atSchedule {
+	sorted := append([]time.Time(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+	return &atSchedule{
+		times: sorted,
+	}
+}
+
+func (s *atSchedule) next(after time.Time) (time.Time, bool) {
+	i := sort.Search(len(s.times), func(i int) bool {
+		return s.times[i].After(after)
+	})
+	if i == len(s.times) {
+		return time.Time{}, false
+	}
+	return s.times[i], true
+}
+
+func (s *atSchedule) String() string {
+	fields := make([]string, len(s.times))
+	for i, t := range s.times {
+		fields[i] = t.Format(time.RFC3339Nano)
+	}
+	return atSchedulePrefix + strings.Join(fields, ",")
+}
+
+const cronTZPrefix = "CRON_TZ="
+
+var cronShorthands = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronDayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+type cronSchedule struct {
+	spec    string
+	loc     *time.Location
+	second  uint64
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+func parseCron(spec string, loc *time.Location) (*cronSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) > 0 && strings.HasPrefix(fields[0], cronTZPrefix) {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(fields[0], cronTZPrefix)); err != nil {
+			return nil, errors.Wrapf(ErrInvalidTickSchedule, "%q: %v", spec, err)
+		}
+		fields = fields[1:]
+	}
+
+	if len(fields) == 1 {
+		if expanded, ok := cronShorthands[fields[0]]; ok {
+			fields = strings.Fields(expanded)
+		}
+	}
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+	if len(fields) != 6 {
+		return nil, errors.Wrapf(ErrInvalidTickSchedule, "%q: expected 5 or 6 fields", spec)
+	}
+
+	c := cronSchedule{
+		loc:     loc,
+		domStar: fields[3] == "*" || fields[3] == "?",
+		dowStar: fields[5] == "*" || fields[5] == "?",
+	}
+
+	var err error
+	if c.second, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, errors.Wrapf(err, "%q second", spec)
+	}
+	if c.minute, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return nil, errors.Wrapf(err, "%q minute", spec)
+	}
+	if c.hour, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return nil, errors.Wrapf(err, "%q hour", spec)
+	}
+	if c.dom, err = parseCronField(fields[3], 1, 31, nil); err != nil {
+		return nil, errors.Wrapf(err, "%q day of month", spec)
+	}
+	if c.month, err = parseCronField(fields[4], 1, 12, cronMonthNames); err != nil {
+		return nil, errors.Wrapf(err, "%q month", spec)
+	}
+	if c.dow, err = parseCronField(fields[5], 0, 7, cronDayNames); err != nil {
+		return nil, errors.Wrapf(err, "%q day of week", spec)
+	}
+	if c.dow&(1<<7) != 0 {
+		// 7 is Sunday too
+		c.dow |= 1
+	}
+
+	c.spec = strings.Join(fields, " ")
+	if loc != time.Local {
+		c.spec = cronTZPrefix + loc.String() + " " + c.spec
+	}
+	return &c, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges (a-b) and steps (*/n or a-b/n) into a bitset
+func parseCronField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, errors.Wrapf(ErrInvalidTickSchedule, "bad step %q", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*" || part == "?":
+		case strings.IndexByte(part, '-') > 0:
+			i := strings.IndexByte(part, '-')
+			var err error
+			if lo, err = parseCronValue(part[:i], min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(part[i+1:], min, max, names); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, errors.Wrapf(ErrInvalidTickSchedule, "bad range %q", part)
+			}
+		default:
+			var err error
+			if lo, err = parseCronValue(part, min, max, names); err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, min int, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, errors.Wrapf(ErrInvalidTickSchedule, "bad value %q", s)
+	}
+	return v, nil
+}
+
+// cronSearchYears bounds how far ahead next() looks, so that schedules that can never match (e.g.: February 30th)
+// don't loop forever
+const cronSearchYears = 5
+
+func (c *cronSchedule) next(after time.Time) (time.Time, bool) {
+	loc := c.loc
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+			continue
+		}
+		if c.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// dayMatches follows the usual cron rule - if both the day of month and the day of week are restricted, either may match
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (c *cronSchedule) String() string {
+	return c.spec
+}
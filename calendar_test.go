@@ -0,0 +1,176 @@
+package actor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type calendarActorTest struct {
+	tickCh chan time.Duration
+}
+
+func newCalendarActorTest() *calendarActorTest {
+	return &calendarActorTest{
+		tickCh: make(chan time.Duration, 10),
+	}
+}
+
+func (a *calendarActorTest) Tick(deltaTime time.Duration) error {
+	a.tickCh <- deltaTime
+	return nil
+}
+
+func (a *calendarActorTest) expectTick(t *testing.T, deltaTime time.Duration) {
+	t.Helper()
+
+	select {
+	case dt := <-a.tickCh:
+		if dt != deltaTime {
+			t.Fatalf("expected a deltaTime of %v, got %v", deltaTime, dt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the actor to tick")
+	}
+}
+
+func (a *calendarActorTest) expectNoTick(t *testing.T) {
+	t.Helper()
+
+	select {
+	case dt := <-a.tickCh:
+		t.Fatalf("expected the actor not to tick, got a tick with a deltaTime of %v", dt)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestTickCron(t *testing.T) {
+	m, clock := startClockTestManager(t, time.Date(2026, 1, 1, 12, 3, 0, 0, time.UTC))
+
+	a := newCalendarActorTest()
+	if err := m.AddActor(a, actor.TickCron("CRON_TZ=UTC 0 */5 * * * *")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetClock(nil); !errors.Is(err, actor.ErrClockInUse) {
+		t.Fatalf("expected ErrClockInUse, got %v", err)
+	}
+
+	stats := m.Stats()
+	if len(stats.TickGroups) != 1 || stats.TickGroups[0].Schedule != "CRON_TZ=UTC 0 */5 * * * *" {
+		t.Fatalf("expected a single cron tick group, got %+v", stats.TickGroups)
+	}
+
+	clock.Advance(time.Minute)
+	a.expectNoTick(t)
+
+	clock.Advance(time.Minute)
+	a.expectTick(t, 2*time.Minute)
+
+	// missed ticks are skipped, like an interval's
+	clock.Advance(12 * time.Minute)
+	a.expectTick(t, 12*time.Minute)
+	a.expectNoTick(t)
+
+	clock.Advance(5 * time.Minute)
+	a.expectTick(t, 5*time.Minute)
+}
+
+func TestTickCronIn(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	m, clock := startClockTestManager(t, time.Date(2026, 1, 2, 13, 59, 0, 0, time.UTC))
+
+	a := newCalendarActorTest()
+	if err := m.AddActor(a, actor.TickCronIn("0 9 * * FRI", est)); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(59 * time.Second)
+	a.expectNoTick(t)
+
+	// 9am EST on Friday the 2nd
+	clock.Advance(time.Second)
+	a.expectTick(t, time.Minute)
+
+	// and again a week later
+	clock.Advance(7*24*time.Hour - time.Second)
+	a.expectNoTick(t)
+	clock.Advance(time.Second)
+	a.expectTick(t, 7*24*time.Hour)
+}
+
+func TestTickAt(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m, clock := startClockTestManager(t, start)
+
+	a := newCalendarActorTest()
+	if err := m.AddActor(a, actor.TickAt(start.Add(2*time.Hour), start.Add(time.Hour), start.Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Hour)
+	a.expectTick(t, time.Hour)
+	clock.Advance(time.Hour)
+	a.expectTick(t, time.Hour)
+	clock.Advance(time.Hour)
+	a.expectNoTick(t)
+}
+
+func TestInvalidTickSchedules(t *testing.T) {
+	m := actor.NewManager()
+	defer m.Stop()
+
+	for _, opt := range []actor.Option{
+		actor.TickCron("* * *"),
+		actor.TickCron("60 * * * * *"),
+		actor.TickCron("0 0 0 * * FUNDAY"),
+		actor.TickCron("0 */0 * * * *"),
+		actor.TickCron("0 5-1 * * * *"),
+		actor.TickAt(),
+		// couldn't be restored from a snapshot
+		actor.TickCronIn("0 0 * * * *", time.FixedZone("UTC+1", 60*60)),
+	} {
+		if err := m.AddActor(newCalendarActorTest(), opt); !errors.Is(err, actor.ErrInvalidTickSchedule) {
+			t.Fatalf("expected ErrInvalidTickSchedule, got %v", err)
+		}
+	}
+}
+
+func TestSnapshotTickSchedule(t *testing.T) {
+	src := startTestManager(t)
+
+	act, err := actor.SpawnActor(reflect.TypeOf(snapshotActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddActor(act, actor.TickCron("@hourly")); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := src.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := snap.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded actor.Snapshot
+	if err := decoded.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Actors[0].TickSchedule != "0 0 * * * *" {
+		t.Fatalf("expected the tick schedule to be kept, got %q", decoded.Actors[0].TickSchedule)
+	}
+
+	dst := startTestManager(t)
+	if _, err := dst.Restore(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if stats := dst.Stats(); len(stats.Actors) != 1 || stats.Actors[0].Schedule != "0 0 * * * *" {
+		t.Fatalf("expected the restored actor to keep its schedule, got %+v", stats.Actors)
+	}
+}
@@ -0,0 +1,207 @@
+package actor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrClockInUse is for when a manager's clock is changed after actors have been added to it
+var ErrClockInUse = errors.New("clock already in use")
+
+// Clock is the source of time a manager schedules its ticks by (see: Manager.SetClock())
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer created by a Clock, which behaves like a time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// SetClock sets the clock the manager schedules its ticks by, or reverts to the system clock if nil
+// it must be set before any actors are added to the manager
+// NOTE: the scheduling of ticks (and the deltaTime passed to them), TellAfter(), significance evaluations, FrameDriver
+// pacing and replication rates follow the clock - tick durations, budgets, timeouts and the like are always measured
+// in real time
+func (m *Manager) SetClock(c Clock) error {
+	if c == nil {
+		c = systemClock{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.actors) > 0 {
+		return ErrClockInUse
+	}
+
+	m.clock = c
+	m.clockGen++
+	m.epoch = c.Now()
+	if ss := m.significance; ss != nil {
+		ss.stop()
+		m.startSignificanceLocked(ss.policy)
+	}
+	m.signalTickGroupsUpdated()
+	return nil
+}
+
+// currentClock returns the manager's clock, for callers that don't hold the manager's lock
+func (m *Manager) currentClock() Clock {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.clock
+}
+
+// FakeClock is a Clock that only moves when told to, for testing scheduled ticks deterministically
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+	// timers holds only the active timers - they're dropped once they fire or are stopped, and added again by Reset()
+	timers []*fakeTimer
+}
+
+// NewFakeClock creates a new fake clock, starting at the time provided
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+// Now returns the fake clock's current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a timer that fires once the fake clock has been moved d past its current time
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the fake clock forward, firing any timers that become due
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.fireLocked()
+	c.mu.Unlock()
+}
+
+// Set moves the fake clock to the time provided, firing any timers that become due
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	c.now = now
+	c.fireLocked()
+	c.mu.Unlock()
+}
+
+func (c *FakeClock) fireLocked() {
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			active = append(active, t)
+			continue
+		}
+		t.fireLocked(c.now)
+	}
+	clear(c.timers[len(active):])
+	c.timers = active
+}
+
+// removeLocked drops a timer that is no longer active
+// c.mu must be held
+func (c *FakeClock) removeLocked(t *fakeTimer) {
+	for i, ct := range c.timers {
+		if ct == t {
+			last := len(c.timers) - 1
+			copy(c.timers[i:], c.timers[i+1:])
+			c.timers[last] = nil
+			c.timers = c.timers[:last]
+			return
+		}
+	}
+}
+
+type fakeTimer struct {
+	clock  *FakeClock
+	ch     chan time.Time
+	when   time.Time
+	active bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := t.active
+	if wasActive {
+		t.active = false
+		t.clock.removeLocked(t)
+	}
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := t.active
+	t.when = t.clock.now.Add(d)
+	if d <= 0 {
+		if wasActive {
+			t.clock.removeLocked(t)
+		}
+		t.fireLocked(t.clock.now)
+		return wasActive
+	}
+	if !wasActive {
+		t.active = true
+		t.clock.timers = append(t.clock.timers, t)
+	}
+	return wasActive
+}
+
+// fireLocked sends the time on the timer's channel, if there is room for it (just like a time.Timer)
+// the clock's lock must be held
+func (t *fakeTimer) fireLocked(now time.Time) {
+	t.active = false
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
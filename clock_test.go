@@ -0,0 +1,64 @@
+package actor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+func expectFakeTimer(t *testing.T, timer actor.Timer, fired bool) {
+	t.Helper()
+
+	select {
+	case <-timer.C():
+		if !fired {
+			t.Fatal("timer fired unexpectedly")
+		}
+	default:
+		if fired {
+			t.Fatal("timer did not fire")
+		}
+	}
+}
+
+func TestFakeClockTimers(t *testing.T) {
+	c := actor.NewFakeClock(time.Unix(0, 0))
+
+	timer := c.NewTimer(time.Second)
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, true)
+	if timer.Stop() {
+		t.Fatal("expected a fired timer to be inactive")
+	}
+
+	// a fired timer fires again once it's reset
+	if timer.Reset(time.Second) {
+		t.Fatal("expected a fired timer to be inactive")
+	}
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, true)
+
+	// a stopped timer doesn't fire until it's reset
+	timer.Reset(time.Second)
+	if !timer.Stop() {
+		t.Fatal("expected a pending timer to be active")
+	}
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, false)
+	timer.Reset(time.Second)
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, true)
+
+	// resetting a pending timer moves it, rather than adding it again
+	timer.Reset(time.Second)
+	if !timer.Reset(2 * time.Second) {
+		t.Fatal("expected a pending timer to be active")
+	}
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, false)
+	c.Advance(time.Second)
+	expectFakeTimer(t, timer, true)
+	c.Advance(2 * time.Second)
+	expectFakeTimer(t, timer, false)
+}
@@ -0,0 +1,75 @@
+package actor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type cowEntry[T any] struct {
+	v T
+}
+
+// cowList is a copy-on-write list, so that going over its values (e.g.: to notify observers) doesn't need a lock
+type cowList[T any] struct {
+	mu      sync.Mutex
+	entries atomic.Pointer[[]*cowEntry[T]]
+}
+
+// add appends the value to the list, returning a function that removes it again
+func (l *cowList[T]) add(v T) func() {
+	e := &cowEntry[T]{
+		v: v,
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entries []*cowEntry[T]
+	if cur := l.entries.Load(); cur != nil {
+		entries = append(entries, *cur...)
+	}
+	entries = append(entries, e)
+	l.entries.Store(&entries)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.remove(e)
+		})
+	}
+}
+
+func (l *cowList[T]) remove(e *cowEntry[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cur := l.entries.Load()
+	if cur == nil {
+		return
+	}
+
+	entries := make([]*cowEntry[T], 0, len(*cur))
+	for _, ce := range *cur {
+		if ce != e {
+			entries = append(entries, ce)
+		}
+	}
+	l.entries.Store(&entries)
+}
+
+func (l *cowList[T]) active() bool {
+	cur := l.entries.Load()
+	return cur != nil && len(*cur) > 0
+}
+
+// each calls fn with every value in the list, as it was when each was called
+func (l *cowList[T]) each(fn func(v T)) {
+	cur := l.entries.Load()
+	if cur == nil {
+		return
+	}
+
+	for _, e := range *cur {
+		fn(e.v)
+	}
+}
@@ -0,0 +1,118 @@
+package actor
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DeadLetterKind is what was being delivered to the target of a dead letter
+type DeadLetterKind int
+
+const (
+	// DeadLetterMessage is a message sent via Tell() or TellFrom()
+	DeadLetterMessage = DeadLetterKind(iota)
+	// DeadLetterAsk is a message sent via Ask() - its asker is handed the dead letter's reason as an error
+	DeadLetterAsk
+	// DeadLetterTimer is a message sent via TellAfter(), whose target was gone once it was due
+	DeadLetterTimer
+	// DeadLetterEvent is an event the manager raised for the target - e.g.: a replication update for a proxy actor
+	DeadLetterEvent
+)
+
+func (k DeadLetterKind) String() string {
+	switch k {
+	case DeadLetterMessage:
+		return "Message"
+	case DeadLetterAsk:
+		return "Ask"
+	case DeadLetterTimer:
+		return "Timer"
+	case DeadLetterEvent:
+		return "Event"
+	default:
+		return fmt.Sprintf("DeadLetterKind(%d)", int(k))
+	}
+}
+
+// DeadLetter is something that couldn't be delivered to its target actor
+type DeadLetter struct {
+	Kind DeadLetterKind
+	// Sender is the actor that sent the message, if it was sent via TellFrom()
+	Sender  Actor
+	Target  Actor
+	Message Message
+	// Reason is why it couldn't be delivered - e.g.: ErrActorNotFound, ErrMailboxFull or ErrManagerStopped
+	Reason error
+}
+
+// envelope is a message on its way to an actor, along with what's needed to report it as a dead letter
+type envelope struct {
+	msg    Message
+	sender Actor
+	kind   DeadLetterKind
+}
+
+// message returns the message as the sender sent it
+func (e envelope) message() Message {
+	if req, ok := e.msg.(*askRequest); ok {
+		return req.msg
+	}
+	return e.msg
+}
+
+// deadLetterList is the list of dead letter observers, which are notified without taking a lock
+type deadLetterList struct {
+	cowList[func(DeadLetter)]
+	count atomic.Uint64
+}
+
+func (l *deadLetterList) notify(dl DeadLetter) {
+	l.count.Add(1)
+
+	l.each(func(fn func(DeadLetter)) {
+		fn(dl)
+	})
+}
+
+// AddDeadLetterObserver registers a function that is called with everything the manager fails to deliver
+// the function returned unregisters it
+// NOTE: the function may be called from any goroutine - including the one that sent the message - so it should be quick,
+// and it must not block on the manager
+func (m *Manager) AddDeadLetterObserver(fn func(DeadLetter)) func() {
+	return m.deadLetters.add(fn)
+}
+
+// SubscribeDeadLetters returns a channel that receives everything the manager fails to deliver from now on, which is
+// handy for debugging and for tests - dead letters that arrive while the channel's buffer is full are discarded
+// the function returned unsubscribes (it doesn't close the channel)
+func (m *Manager) SubscribeDeadLetters(buffer int) (<-chan DeadLetter, func()) {
+	ch := make(chan DeadLetter, buffer)
+	unsubscribe := m.deadLetters.add(func(dl DeadLetter) {
+		select {
+		case ch <- dl:
+		default:
+			// nobody's keeping up
+		}
+	})
+	return ch, unsubscribe
+}
+
+// undeliverable fails any asks among the messages provided, then hands them all to the dead letter observers
+func (m *Manager) undeliverable(a Actor, envs []envelope, reason error) {
+	failAsks(envs, reason)
+
+	for _, env := range envs {
+		m.deadLetters.notify(DeadLetter{
+			Kind:    env.kind,
+			Sender:  env.sender,
+			Target:  a,
+			Message: env.message(),
+			Reason:  reason,
+		})
+	}
+}
+
+// discardMailbox closes the actor's mailbox, treating whatever was left in it as undeliverable
+func (m *Manager) discardMailbox(a Actor, ami actorMgrInfo, reason error) {
+	m.undeliverable(a, ami.mailbox.close(), reason)
+}
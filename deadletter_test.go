@@ -0,0 +1,145 @@
+package actor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+func expectDeadLetter(t *testing.T, ch <-chan actor.DeadLetter) actor.DeadLetter {
+	t.Helper()
+
+	select {
+	case dl := <-ch:
+		return dl
+	case <-time.After(time.Second):
+		t.Fatal("expected a dead letter")
+		return actor.DeadLetter{}
+	}
+}
+
+func TestDeadLetterRemovedActor(t *testing.T) {
+	sender := newMailboxActorTest()
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a)
+
+	dead, unsubscribe := m.SubscribeDeadLetters(8)
+	defer unsubscribe()
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.TellFrom(sender, a, "hello"); !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+
+	dl := expectDeadLetter(t, dead)
+	if dl.Kind != actor.DeadLetterMessage || dl.Sender != sender || dl.Target != a || dl.Message != "hello" || !errors.Is(dl.Reason, actor.ErrActorNotFound) {
+		t.Fatalf("unexpected dead letter %+v", dl)
+	}
+
+	if _, err := m.Ask(context.Background(), a, "question"); !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+	if dl := expectDeadLetter(t, dead); dl.Kind != actor.DeadLetterAsk || dl.Message != "question" {
+		t.Fatalf("unexpected dead letter %+v", dl)
+	}
+
+	if n := m.Stats().DeadLetters; n != 2 {
+		t.Fatalf("expected 2 dead letters, got %d", n)
+	}
+}
+
+func TestDeadLetterPendingMessages(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a)
+	a.hold(t, m)
+
+	dead, unsubscribe := m.SubscribeDeadLetters(8)
+	defer unsubscribe()
+
+	if err := m.Tell(a, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	asked := make(chan error, 1)
+	go func() {
+		_, err := m.Ask(context.Background(), a, 2)
+		asked <- err
+	}()
+	for m.Stats().Actors[0].MailboxLen != 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+	close(a.gate)
+
+	if err := <-asked; !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+	for _, want := range []actor.Message{1, 2} {
+		if dl := expectDeadLetter(t, dead); dl.Message != want || dl.Target != a {
+			t.Fatalf("expected message %v to be a dead letter, got %+v", want, dl)
+		}
+	}
+}
+
+func TestDeadLetterTimer(t *testing.T) {
+	m, clock := startClockTestManager(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	a := newMailboxActorTest()
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	dead, unsubscribe := m.SubscribeDeadLetters(8)
+	defer unsubscribe()
+
+	m.TellAfter(time.Second, a, "delivered")
+	cancel := m.TellAfter(time.Second, a, "cancelled")
+	if !cancel() {
+		t.Fatal("expected the timer to be cancelled")
+	}
+	clock.Advance(time.Second)
+	a.expect(t, "delivered")
+
+	m.TellAfter(time.Minute, a, "late")
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+
+	dl := expectDeadLetter(t, dead)
+	if dl.Kind != actor.DeadLetterTimer || dl.Message != "late" || !errors.Is(dl.Reason, actor.ErrActorNotFound) {
+		t.Fatalf("unexpected dead letter %+v", dl)
+	}
+
+	select {
+	case dl := <-dead:
+		t.Fatalf("unexpected dead letter %+v", dl)
+	default:
+	}
+}
+
+func TestDeadLetterManagerStopped(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a)
+
+	dead, unsubscribe := m.SubscribeDeadLetters(8)
+	defer unsubscribe()
+
+	m.Stop()
+
+	if err := m.Tell(a, "hello"); !errors.Is(err, actor.ErrManagerStopped) {
+		t.Fatalf("expected ErrManagerStopped, got %v", err)
+	}
+	if dl := expectDeadLetter(t, dead); !errors.Is(dl.Reason, actor.ErrManagerStopped) {
+		t.Fatalf("unexpected dead letter %+v", dl)
+	}
+}
@@ -0,0 +1,192 @@
+package actor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidFrameRate is for when a negative target frame rate is provided to a FrameDriver
+	ErrInvalidFrameRate = errors.New("invalid frame rate")
+
+	// ErrInvalidFrameSmoothing is for when a frame smoothing factor outside of (0, 1] is provided to a FrameDriver
+	ErrInvalidFrameSmoothing = errors.New("invalid frame smoothing factor")
+)
+
+// DefaultFrameSmoothing is the default weight given to the most recent frame time when smoothing frame times
+const DefaultFrameSmoothing = 0.1
+
+type frameDriverSettings struct {
+	frameRate float64
+	vsync     bool
+	smoothing float64
+}
+
+// FrameDriverOption is a function that sets up an option during the NewFrameDriver function
+type FrameDriverOption func(*frameDriverSettings) error
+
+// TargetFrameRate sets the number of frames per second the driver aims for
+// zero (the default) ticks frames as fast as possible
+func TargetFrameRate(fps float64) FrameDriverOption {
+	return func(s *frameDriverSettings) error {
+		if fps < 0 {
+			return errors.Wrapf(ErrInvalidFrameRate, "%v fps", fps)
+		}
+
+		s.frameRate = fps
+		return nil
+	}
+}
+
+// VSync makes the driver wait for the next frame boundary (as laid out from when Run was called) when a frame runs long,
+// rather than starting the next frame immediately - it has no effect without a TargetFrameRate
+func VSync() FrameDriverOption {
+	return func(s *frameDriverSettings) error {
+		s.vsync = true
+		return nil
+	}
+}
+
+// FrameSmoothing sets the weight (0, 1] given to the most recent frame time when smoothing frame times
+// 1 disables smoothing altogether
+func FrameSmoothing(factor float64) FrameDriverOption {
+	return func(s *frameDriverSettings) error {
+		if factor <= 0 || factor > 1 {
+			return errors.Wrapf(ErrInvalidFrameSmoothing, "%v", factor)
+		}
+
+		s.smoothing = factor
+		return nil
+	}
+}
+
+// FrameStats are the measurements a FrameDriver takes of the frames it drives
+type FrameStats struct {
+	Frames            uint64
+	LastFrameTime     time.Duration
+	SmoothedFrameTime time.Duration
+	// FrameRate is the number of frames per second, based on SmoothedFrameTime
+	FrameRate float64
+}
+
+// FrameDriver calls a manager's TickFrameSync() function in a loop, so that callers don't have to
+type FrameDriver struct {
+	m        *Manager
+	settings frameDriverSettings
+
+	mu    sync.Mutex
+	stats FrameStats
+}
+
+// NewFrameDriver creates a new frame driver for the manager provided
+func NewFrameDriver(m *Manager, opts ...FrameDriverOption) (*FrameDriver, error) {
+	s := frameDriverSettings{
+		smoothing: DefaultFrameSmoothing,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	d := FrameDriver{
+		m:        m,
+		settings: s,
+	}
+
+	return &d, nil
+}
+
+// Run ticks frames until the context is cancelled (returning its error) or the manager stops (returning ErrManagerStopped)
+func (d *FrameDriver) Run(ctx context.Context) error {
+	var period time.Duration
+	if d.settings.frameRate > 0 {
+		period = time.Duration(float64(time.Second) / d.settings.frameRate)
+	}
+
+	clock := d.m.currentClock()
+
+	var timer Timer
+	if period > 0 {
+		timer = clock.NewTimer(period)
+		defer timer.Stop()
+	}
+
+	origin := clock.Now()
+	frameStart := origin
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := d.m.TickFrameSync(ctx); err != nil {
+			var ferr *FrameError
+			if !errors.As(err, &ferr) {
+				return err
+			}
+			// the actors' errors have already been logged by the manager
+		}
+
+		if period > 0 {
+			now := clock.Now()
+			wake := frameStart.Add(period)
+			if d.settings.vsync && !wake.After(now) {
+				// missed the boundary - wait for the next one
+				wake = origin.Add((now.Sub(origin)/period + 1) * period)
+			}
+
+			if wait := wake.Sub(now); wait > 0 {
+				if !timer.Stop() {
+					select {
+					case <-timer.C():
+					default:
+					}
+				}
+				timer.Reset(wait)
+
+				select {
+				case <-timer.C():
+				case <-d.m.context().Done():
+					return ErrManagerStopped
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		now := clock.Now()
+		d.record(now.Sub(frameStart))
+		frameStart = now
+	}
+}
+
+func (d *FrameDriver) record(frameTime time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Frames++
+	d.stats.LastFrameTime = frameTime
+	if d.stats.Frames == 1 {
+		d.stats.SmoothedFrameTime = frameTime
+	} else {
+		smoothed := d.settings.smoothing*float64(frameTime) + (1-d.settings.smoothing)*float64(d.stats.SmoothedFrameTime)
+		d.stats.SmoothedFrameTime = time.Duration(smoothed)
+	}
+	if d.stats.SmoothedFrameTime > 0 {
+		d.stats.FrameRate = float64(time.Second) / float64(d.stats.SmoothedFrameTime)
+	}
+}
+
+// Stats returns the measurements taken of the frames driven so far
+func (d *FrameDriver) Stats() FrameStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.stats
+}
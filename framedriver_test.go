@@ -0,0 +1,164 @@
+package actor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type frameActorTest struct {
+	frames int32
+}
+
+func (a *frameActorTest) Tick(deltaTime time.Duration) error {
+	atomic.AddInt32(&a.frames, 1)
+	return nil
+}
+
+func TestFrameDriver(t *testing.T) {
+	if _, err := actor.NewFrameDriver(actor.NewManager(), actor.FrameSmoothing(2)); !errors.Is(err, actor.ErrInvalidFrameSmoothing) {
+		t.Fatalf("expected ErrInvalidFrameSmoothing, got %v", err)
+	}
+
+	m := actor.NewManager()
+	m.StartTicking(context.Background())
+
+	a := &frameActorTest{}
+	if err := m.AddActor(a, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := actor.NewFrameDriver(m, actor.TargetFrameRate(100), actor.VSync())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := d.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	stats := d.Stats()
+	if stats.Frames < 5 || stats.Frames > 25 {
+		t.Fatalf("expected around 20 frames at 100fps, got %+v", stats)
+	}
+	if stats.SmoothedFrameTime < 5*time.Millisecond {
+		t.Fatalf("frames not limited to the target frame rate - got %+v", stats)
+	}
+	if atomic.LoadInt32(&a.frames) == 0 {
+		t.Fatal("every-frame actor did not tick")
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- d.Run(context.Background())
+	}()
+	time.Sleep(20 * time.Millisecond)
+	m.Stop()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, actor.ErrManagerStopped) {
+			t.Fatalf("expected ErrManagerStopped, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("frame driver did not stop with the manager")
+	}
+}
+
+var errFrameActorTest = errors.New("frame actor test error")
+
+type failingFrameActorTest struct {
+	frameActorTest
+}
+
+func (a *failingFrameActorTest) Tick(deltaTime time.Duration) error {
+	a.frameActorTest.Tick(deltaTime)
+	return errFrameActorTest
+}
+
+func TestTickFrameSync(t *testing.T) {
+	m := startTestManager(t)
+
+	// frames complete even without any Every-Frame actors
+	if err := m.TickFrameSync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if frame := <-m.FrameCompleted(); frame != 1 || m.Frame() != 1 {
+		t.Fatalf("expected frame 1, got %d (%d)", frame, m.Frame())
+	}
+
+	a := &frameActorTest{}
+	if err := m.AddActor(a, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := m.TickFrameSync(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if frames := atomic.LoadInt32(&a.frames); frames != int32(i+1) {
+			t.Fatalf("expected %d frames ticked on return, got %d", i+1, frames)
+		}
+	}
+
+	if frame := <-m.FrameCompleted(); frame != 4 {
+		t.Fatalf("expected only the latest frame to be kept, got %d", frame)
+	}
+
+	if err := m.AddActor(&failingFrameActorTest{}, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	err := m.TickFrameSync(context.Background())
+	var ferr *actor.FrameError
+	if !errors.As(err, &ferr) || ferr.Frame != 5 || len(ferr.Errors) != 1 {
+		t.Fatalf("expected a FrameError for frame 5 with 1 error, got %v", err)
+	}
+	if !errors.Is(err, errFrameActorTest) {
+		t.Fatalf("expected FrameError to wrap the actor's error, got %v", err)
+	}
+}
+
+func TestFrameDriverFollowsClock(t *testing.T) {
+	m, clock := startClockTestManager(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	d, err := actor.NewFrameDriver(m, actor.TargetFrameRate(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- d.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-errCh
+	}()
+
+	// the first frame doesn't finish until the clock reaches the next frame boundary
+	time.Sleep(20 * time.Millisecond)
+	if stats := d.Stats(); stats.Frames != 0 {
+		t.Fatalf("expected no frames to finish without the clock moving, got %+v", stats)
+	}
+
+	const period = 100 * time.Millisecond
+	deadline := time.Now().Add(5 * time.Second)
+	for d.Stats().Frames == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("frame did not finish once the clock moved")
+		}
+		clock.Advance(period)
+		time.Sleep(time.Millisecond)
+	}
+	if stats := d.Stats(); stats.LastFrameTime <= 0 || stats.LastFrameTime%period != 0 {
+		t.Fatalf("expected the frame time to be measured on the clock, got %+v", stats)
+	}
+}
@@ -0,0 +1,273 @@
+package actor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoFutures is for when Any() is given no futures to wait on
+	ErrNoFutures = errors.New("no futures")
+	// ErrNilFuture is for when the function passed to ThenFuture() returns a nil future
+	ErrNilFuture = errors.New("nil future")
+)
+
+// Future is the result of an asynchronous request (see: Manager.AskAsync()), which completes once with a value or
+// an error. Callbacks registered on it run on its manager's tick goroutine, so actors may wait on futures from their
+// own callbacks without blocking the manager - and without having to synchronize with their own Tick()
+type Future[T any] struct {
+	m      *Manager
+	doneCh chan struct{}
+
+	mu      sync.Mutex
+	done    bool
+	value   T
+	err     error
+	waiters []func()
+}
+
+// NewFuture creates a new future whose callbacks run on the tick goroutine of the manager provided (or right away, on
+// whichever goroutine completes it, if the manager is nil) - along with the function that completes it
+// only the first call to the function completes the future, which it reports by returning true
+func NewFuture[T any](m *Manager) (*Future[T], func(T, error) bool) {
+	f := &Future[T]{
+		m:      m,
+		doneCh: make(chan struct{}),
+	}
+	return f, f.complete
+}
+
+// FutureResult is the message an actor is sent when a future is piped to it (see: Future.PipeTo())
+type FutureResult[T any] struct {
+	Value T
+	Err   error
+}
+
+func (f *Future[T]) complete(value T, err error) bool {
+	f.mu.Lock()
+	if f.done {
+		f.mu.Unlock()
+		return false
+	}
+	f.done = true
+	f.value = value
+	f.err = err
+	waiters := f.waiters
+	f.waiters = nil
+	close(f.doneCh)
+	f.mu.Unlock()
+
+	for _, fn := range waiters {
+		fn()
+	}
+	return true
+}
+
+// onDone runs the function right away once the future completes, on whichever goroutine completes it
+func (f *Future[T]) onDone(fn func()) {
+	f.mu.Lock()
+	if !f.done {
+		f.waiters = append(f.waiters, fn)
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+
+	fn()
+}
+
+// run runs the function on the future's manager's tick goroutine
+func (f *Future[T]) run(fn func()) {
+	if f.m == nil {
+		fn()
+		return
+	}
+	f.m.post(fn)
+}
+
+// Done returns a channel that is closed once the future completes
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.doneCh
+}
+
+// Await waits for the future to complete, returning its result, or the context's error if it's done first
+// NOTE: this must not be called from the manager's tick goroutine (e.g.: from within Tick()) for a future that the
+// manager completes - e.g.: one from AskAsync() - as it would never complete. Use OnComplete() instead
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.doneCh:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// OnComplete calls the function with the future's result on its manager's tick goroutine, once it completes
+// NOTE: callbacks still waiting to run when the manager stops are never called
+func (f *Future[T]) OnComplete(fn func(value T, err error)) {
+	f.onDone(func() {
+		f.run(func() {
+			fn(f.value, f.err)
+		})
+	})
+}
+
+// PipeTo sends the future's result to the actor referred to as a FutureResult, once it completes
+func (f *Future[T]) PipeTo(ref ActorRef) {
+	f.onDone(func() {
+		_ = ref.Tell(FutureResult[T]{
+			Value: f.value,
+			Err:   f.err,
+		})
+	})
+}
+
+// DeliverOn returns a future that completes along with this one, but whose callbacks run on the manager provided
+// e.g.: for an actor asking an actor in another manager, so that its callbacks run on its own manager's tick goroutine
+func (f *Future[T]) DeliverOn(m *Manager) *Future[T] {
+	next, complete := NewFuture[T](m)
+	f.onDone(func() {
+		complete(f.value, f.err)
+	})
+	return next
+}
+
+// WithContext returns a future that completes along with this one, or with the context's error if it's done first
+func (f *Future[T]) WithContext(ctx context.Context) *Future[T] {
+	next, complete := NewFuture[T](f.m)
+	stop := context.AfterFunc(ctx, func() {
+		var zero T
+		complete(zero, ctx.Err())
+	})
+	f.onDone(func() {
+		stop()
+		complete(f.value, f.err)
+	})
+	return next
+}
+
+// Then returns a future that completes with the result of calling the function with the future's value, once it
+// completes - the function runs on the future's manager's tick goroutine, and isn't called if the future fails
+func Then[T any, U any](f *Future[T], fn func(T) (U, error)) *Future[U] {
+	next, complete := NewFuture[U](f.m)
+	f.onDone(func() {
+		if f.err != nil {
+			var zero U
+			complete(zero, f.err)
+			return
+		}
+		f.run(func() {
+			complete(fn(f.value))
+		})
+	})
+	return next
+}
+
+// ThenFuture is like Then(), for functions that continue with another asynchronous request - e.g.: asking another actor
+// with the value of the first ask - and returns a future that completes along with the future the function returns
+// (or with ErrNilFuture, if it returns nil)
+func ThenFuture[T any, U any](f *Future[T], fn func(T) *Future[U]) *Future[U] {
+	next, complete := NewFuture[U](f.m)
+	f.onDone(func() {
+		if f.err != nil {
+			var zero U
+			complete(zero, f.err)
+			return
+		}
+		f.run(func() {
+			inner := fn(f.value)
+			if inner == nil {
+				var zero U
+				complete(zero, ErrNilFuture)
+				return
+			}
+			inner.onDone(func() {
+				complete(inner.value, inner.err)
+			})
+		})
+	})
+	return next
+}
+
+// All returns a future that completes with the values of all of the futures provided, in the order provided, once
+// they've all completed - or with the error of the first to fail
+// its callbacks run on the manager of the first future provided
+func All[T any](fs ...*Future[T]) *Future[[]T] {
+	var m *Manager
+	if len(fs) > 0 {
+		m = fs[0].m
+	}
+
+	all, complete := NewFuture[[]T](m)
+	if len(fs) == 0 {
+		complete(nil, nil)
+		return all
+	}
+
+	values := make([]T, len(fs))
+	var mu sync.Mutex
+	remaining := len(fs)
+	for i, f := range fs {
+		i, f := i, f
+		f.onDone(func() {
+			if f.err != nil {
+				complete(nil, f.err)
+				return
+			}
+
+			mu.Lock()
+			values[i] = f.value
+			remaining--
+			finished := remaining == 0
+			mu.Unlock()
+
+			if finished {
+				complete(values, nil)
+			}
+		})
+	}
+	return all
+}
+
+// Any returns a future that completes with the value of the first of the futures provided to succeed - or, if they
+// all fail, with the error of the last of them to fail
+// its callbacks run on the manager of the first future provided
+func Any[T any](fs ...*Future[T]) *Future[T] {
+	var m *Manager
+	if len(fs) > 0 {
+		m = fs[0].m
+	}
+
+	anyf, complete := NewFuture[T](m)
+	if len(fs) == 0 {
+		var zero T
+		complete(zero, ErrNoFutures)
+		return anyf
+	}
+
+	var mu sync.Mutex
+	remaining := len(fs)
+	for _, f := range fs {
+		f := f
+		f.onDone(func() {
+			if f.err == nil {
+				complete(f.value, nil)
+				return
+			}
+
+			mu.Lock()
+			remaining--
+			failed := remaining == 0
+			mu.Unlock()
+
+			if failed {
+				var zero T
+				complete(zero, f.err)
+			}
+		})
+	}
+	return anyf
+}
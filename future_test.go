@@ -0,0 +1,214 @@
+package actor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type futureDoublerTest struct{}
+
+func (futureDoublerTest) Respond(msg actor.Message) (actor.Message, error) {
+	n, ok := msg.(int)
+	if !ok {
+		return nil, errors.New("not a number")
+	}
+	return n * 2, nil
+}
+
+// futureAskerTest asks the doubler from its Tick(), and is handed the reply on the tick goroutine - the race
+// detector would complain about its plain fields otherwise
+type futureAskerTest struct {
+	m       *actor.Manager
+	doubler actor.Actor
+	asked   bool
+	reply   actor.Message
+	err     error
+	done    bool
+	doneCh  chan struct{}
+}
+
+func (a *futureAskerTest) Tick(deltaTime time.Duration) error {
+	if a.done {
+		if a.doneCh != nil {
+			close(a.doneCh)
+			a.doneCh = nil
+		}
+		return nil
+	}
+	if a.asked {
+		return nil
+	}
+
+	a.asked = true
+	a.m.AskAsync(context.Background(), a.doubler, 21).OnComplete(func(reply actor.Message, err error) {
+		a.reply = reply
+		a.err = err
+		a.done = true
+	})
+	return nil
+}
+
+func startFutureTest(t *testing.T) (*actor.Manager, actor.Actor) {
+	t.Helper()
+
+	m := startTestManager(t)
+
+	doubler := &futureDoublerTest{}
+	if err := m.AddActor(doubler, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	return m, doubler
+}
+
+func TestAskAsyncFromTick(t *testing.T) {
+	m, doubler := startFutureTest(t)
+
+	doneCh := make(chan struct{})
+	asker := &futureAskerTest{
+		m:       m,
+		doubler: doubler,
+		doneCh:  doneCh,
+	}
+	if err := m.AddActor(asker, actor.TickInterval(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-doneCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected the asker to be handed its reply")
+	}
+
+	if err := m.RemoveActor(asker, nil); err != nil {
+		t.Fatal(err)
+	}
+	if asker.err != nil || asker.reply != 42 {
+		t.Fatalf("expected 42, got %v (%v)", asker.reply, asker.err)
+	}
+}
+
+func TestAskAsyncThen(t *testing.T) {
+	m, doubler := startFutureTest(t)
+	ctx := context.Background()
+
+	f := actor.ThenFuture(m.AskAsync(ctx, doubler, 1), func(reply actor.Message) *actor.Future[actor.Message] {
+		return m.AskAsync(ctx, doubler, reply)
+	})
+	s := actor.Then(f, func(reply actor.Message) (string, error) {
+		return fmt.Sprint("got ", reply), nil
+	})
+
+	v, err := s.Await(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "got 4" {
+		t.Fatalf("expected \"got 4\", got %q", v)
+	}
+
+	failed := actor.Then(m.AskAsync(ctx, doubler, "nope"), func(reply actor.Message) (int, error) {
+		t.Error("expected Then() to skip a failed future")
+		return 0, nil
+	})
+	if _, err := failed.Await(ctx); err == nil {
+		t.Fatal("expected the failure to carry through")
+	}
+
+	missing := actor.ThenFuture(m.AskAsync(ctx, doubler, 1), func(reply actor.Message) *actor.Future[int] {
+		return nil
+	})
+	if _, err := missing.Await(ctx); !errors.Is(err, actor.ErrNilFuture) {
+		t.Fatalf("expected ErrNilFuture, got %v", err)
+	}
+}
+
+func TestAskAsyncTimeout(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a)
+	a.hold(t, m)
+	defer close(a.gate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := m.AskAsync(ctx, a, "question").Await(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestFutureAllAny(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	f1, complete1 := actor.NewFuture[int](nil)
+	f2, complete2 := actor.NewFuture[int](nil)
+	f3, complete3 := actor.NewFuture[int](nil)
+
+	all := actor.All(f1, f2, f3)
+	anyf := actor.Any(f1, f2, f3)
+
+	complete2(2, nil)
+	if v, err := anyf.Await(ctx); err != nil || v != 2 {
+		t.Fatalf("expected 2, got %v (%v)", v, err)
+	}
+
+	complete3(3, nil)
+	complete1(1, nil)
+	if complete1(10, nil) {
+		t.Fatal("expected a future to only complete once")
+	}
+	vs, err := all.Await(ctx)
+	if err != nil || len(vs) != 3 || vs[0] != 1 || vs[1] != 2 || vs[2] != 3 {
+		t.Fatalf("expected [1 2 3], got %v (%v)", vs, err)
+	}
+
+	f4, complete4 := actor.NewFuture[int](nil)
+	f5, complete5 := actor.NewFuture[int](nil)
+	all = actor.All(f4, f5)
+	anyf = actor.Any(f4, f5)
+	complete4(0, errFailed)
+	if _, err := all.Await(ctx); !errors.Is(err, errFailed) {
+		t.Fatalf("expected errFailed, got %v", err)
+	}
+	select {
+	case <-anyf.Done():
+		t.Fatal("expected Any() to wait for the other future")
+	default:
+	}
+	complete5(0, errFailed)
+	if _, err := anyf.Await(ctx); !errors.Is(err, errFailed) {
+		t.Fatalf("expected errFailed, got %v", err)
+	}
+
+	if _, err := actor.Any[int]().Await(ctx); !errors.Is(err, actor.ErrNoFutures) {
+		t.Fatalf("expected ErrNoFutures, got %v", err)
+	}
+}
+
+func TestFuturePipeTo(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a)
+
+	f, complete := actor.NewFuture[int](m)
+	f.PipeTo(m.Ref(a))
+	complete(7, nil)
+
+	a.expect(t, actor.FutureResult[int]{Value: 7})
+}
+
+func TestFutureWithContext(t *testing.T) {
+	f, _ := actor.NewFuture[int](nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wf := f.WithContext(ctx)
+	cancel()
+
+	if _, err := wf.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
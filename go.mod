@@ -1,5 +1,5 @@
 module github.com/heucuva/actor
 
-go 1.15
+go 1.21
 
 require github.com/pkg/errors v0.9.1
@@ -0,0 +1,231 @@
+package actor
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSnapshotNotFound is for when a journal has no snapshot saved for a persistence ID
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// JournalEntry is a single event stored in a journal
+type JournalEntry struct {
+	SequenceNr uint64 `json:"seq"`
+	Class      string `json:"class"`
+	Data       []byte `json:"data"`
+}
+
+// Journal is a pluggable store for the events and snapshots of persistent actors
+type Journal interface {
+	// Append adds entries to the end of the journal for the persistence ID
+	Append(persistenceID string, entries []JournalEntry) error
+	// Replay calls fn for every entry for the persistence ID with a sequence number of at least fromSequenceNr, in order
+	Replay(persistenceID string, fromSequenceNr uint64, fn func(JournalEntry) error) error
+	// SaveSnapshot stores the state of the persistent actor as of the sequence number provided
+	SaveSnapshot(persistenceID string, sequenceNr uint64, data []byte) error
+	// LoadSnapshot returns the latest snapshot for the persistence ID, or ErrSnapshotNotFound
+	LoadSnapshot(persistenceID string) (uint64, []byte, error)
+}
+
+type journalSnapshot struct {
+	SequenceNr uint64 `json:"seq"`
+	Data       []byte `json:"data"`
+}
+
+// MemoryJournal is a Journal that keeps everything in memory
+type MemoryJournal struct {
+	mu        sync.RWMutex
+	entries   map[string][]JournalEntry
+	snapshots map[string]journalSnapshot
+}
+
+// NewMemoryJournal creates a new in-memory journal
+func NewMemoryJournal() *MemoryJournal {
+	j := MemoryJournal{
+		entries:   make(map[string][]JournalEntry),
+		snapshots: make(map[string]journalSnapshot),
+	}
+
+	return &j
+}
+
+// Append adds entries to the end of the journal for the persistence ID
+func (j *MemoryJournal) Append(persistenceID string, entries []JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries[persistenceID] = append(j.entries[persistenceID], entries...)
+	return nil
+}
+
+// Replay calls fn for every entry for the persistence ID with a sequence number of at least fromSequenceNr, in order
+func (j *MemoryJournal) Replay(persistenceID string, fromSequenceNr uint64, fn func(JournalEntry) error) error {
+	j.mu.RLock()
+	entries := j.entries[persistenceID]
+	j.mu.RUnlock()
+
+	for _, entry := range entries {
+		if entry.SequenceNr < fromSequenceNr {
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot stores the state of the persistent actor as of the sequence number provided
+func (j *MemoryJournal) SaveSnapshot(persistenceID string, sequenceNr uint64, data []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.snapshots[persistenceID] = journalSnapshot{
+		SequenceNr: sequenceNr,
+		Data:       data,
+	}
+	return nil
+}
+
+// LoadSnapshot returns the latest snapshot for the persistence ID, or ErrSnapshotNotFound
+func (j *MemoryJournal) LoadSnapshot(persistenceID string) (uint64, []byte, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	snap, found := j.snapshots[persistenceID]
+	if !found {
+		return 0, nil, ErrSnapshotNotFound
+	}
+	return snap.SequenceNr, snap.Data, nil
+}
+
+// FileJournal is a Journal that stores each persistence ID's events and latest snapshot as files in a directory
+type FileJournal struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileJournal creates a new file-based journal in the directory provided, creating it if necessary
+func NewFileJournal(dir string) (*FileJournal, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	j := FileJournal{
+		dir: dir,
+	}
+
+	return &j, nil
+}
+
+func (j *FileJournal) path(persistenceID string, ext string) string {
+	return filepath.Join(j.dir, url.PathEscape(persistenceID)+ext)
+}
+
+// Append adds entries to the end of the journal for the persistence ID
+func (j *FileJournal) Append(persistenceID string, entries []JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.OpenFile(j.path(persistenceID, ".journal"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Replay calls fn for every entry for the persistence ID with a sequence number of at least fromSequenceNr, in order
+func (j *FileJournal) Replay(persistenceID string, fromSequenceNr uint64, fn func(JournalEntry) error) error {
+	j.mu.Lock()
+	f, err := os.Open(j.path(persistenceID, ".journal"))
+	j.mu.Unlock()
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var entry JournalEntry
+		if err := dec.Decode(&entry); err != nil {
+			return err
+		}
+		if entry.SequenceNr < fromSequenceNr {
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot stores the state of the persistent actor as of the sequence number provided
+func (j *FileJournal) SaveSnapshot(persistenceID string, sequenceNr uint64, data []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := json.Marshal(journalSnapshot{
+		SequenceNr: sequenceNr,
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	// write then rename, so a crash never leaves a partial snapshot behind
+	path := j.path(persistenceID, ".snapshot")
+	if err := os.WriteFile(path+".tmp", b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// LoadSnapshot returns the latest snapshot for the persistence ID, or ErrSnapshotNotFound
+func (j *FileJournal) LoadSnapshot(persistenceID string) (uint64, []byte, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := os.ReadFile(j.path(persistenceID, ".snapshot"))
+	if os.IsNotExist(err) {
+		return 0, nil, ErrSnapshotNotFound
+	} else if err != nil {
+		return 0, nil, err
+	}
+
+	var snap journalSnapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return 0, nil, err
+	}
+	return snap.SequenceNr, snap.Data, nil
+}
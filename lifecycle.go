@@ -0,0 +1,270 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidLifecycleState is for when a lifecycle function is called on an actor that isn't in a state that allows it
+// the errors returned are *LifecycleError values, which match this via errors.Is()
+var ErrInvalidLifecycleState = errors.New("invalid lifecycle state")
+
+// LifecycleState is the stage of its lifecycle that an actor has reached
+type LifecycleState int32
+
+const (
+	// StateUnknown is for actors that are not tracked - either never spawned via SpawnActor() nor added to a manager,
+	// or already Destroyed (actors stop being tracked once destroyed, so that they may be garbage collected)
+	StateUnknown = LifecycleState(iota)
+	// StateAllocated is for actors that have been created by SpawnActor(), but not yet had PostSpawnInitialize() called
+	StateAllocated
+	// StatePostSpawnInitialized is for actors that are waiting on FinishSpawningActor() to be called (including idle pooled actors)
+	StatePostSpawnInitialized
+	// StateConstructing is for actors in the middle of FinishSpawningActor()
+	StateConstructing
+	// StateSpawned is for actors that have finished spawning, but have not yet been added to a manager
+	StateSpawned
+	// StateLoading is for actors that have been added to a manager and are waiting on their asynchronous BeginPlay
+	// to finish (see: AsyncBeginPlayIntf) - they aren't ticked until they start Playing
+	StateLoading
+	// StatePlaying is for actors that have been added to a manager and had BeginPlay() called
+	StatePlaying
+	// StateEndingPlay is for actors in the middle of EndPlay()
+	StateEndingPlay
+	// StatePendingKill is for actors that have ended play and are being destroyed
+	StatePendingKill
+	// StateDestroyed is for actors that have had FinishDestroy() called
+	StateDestroyed
+)
+
+func (s LifecycleState) String() string {
+	switch s {
+	case StateUnknown:
+		return "Unknown"
+	case StateAllocated:
+		return "Allocated"
+	case StatePostSpawnInitialized:
+		return "PostSpawnInitialized"
+	case StateConstructing:
+		return "Constructing"
+	case StateSpawned:
+		return "Spawned"
+	case StateLoading:
+		return "Loading"
+	case StatePlaying:
+		return "Playing"
+	case StateEndingPlay:
+		return "EndingPlay"
+	case StatePendingKill:
+		return "PendingKill"
+	case StateDestroyed:
+		return "Destroyed"
+	default:
+		return fmt.Sprintf("LifecycleState(%d)", int32(s))
+	}
+}
+
+// LifecycleError is for when Op was attempted on an actor in a State that doesn't allow it
+type LifecycleError struct {
+	Op    string
+	State LifecycleState
+}
+
+func (e *LifecycleError) Error() string {
+	return fmt.Sprintf("%s: %v (actor is %v)", e.Op, ErrInvalidLifecycleState, e.State)
+}
+
+// Is lets errors.Is() match a LifecycleError against ErrInvalidLifecycleState
+func (e *LifecycleError) Is(target error) bool {
+	return target == ErrInvalidLifecycleState
+}
+
+type lifecycle struct {
+	state atomic.Int32
+	// pool is the pool the actor is returned to once it's removed from its manager, if any
+	pool *ActorPool
+	// adopted is set for actors that were first tracked when they were added to a manager (rather than spawned via
+	// SpawnActor), which stop being tracked again if they leave it without being destroyed
+	adopted bool
+}
+
+func (l *lifecycle) load() LifecycleState {
+	return LifecycleState(l.state.Load())
+}
+
+// transition moves the lifecycle from any one of the states provided to the next one
+func (l *lifecycle) transition(op string, to LifecycleState, from ...LifecycleState) error {
+	for {
+		cur := l.load()
+		allowed := false
+		for _, f := range from {
+			if cur == f {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return &LifecycleError{
+				Op:    op,
+				State: cur,
+			}
+		}
+
+		if l.state.CompareAndSwap(int32(cur), int32(to)) {
+			return nil
+		}
+	}
+}
+
+// lifecycles holds the lifecycle of every tracked actor
+// actors are only untracked once they're destroyed (or forgotten), so spawned actors that are dropped without
+// DestroyActor(), and the actors of managers that are dropped without Stop(), stay tracked until ForgetActor() is called
+var lifecycles sync.Map
+
+// trackLifecycle returns the actor's lifecycle, which starts out in the state provided if it isn't tracked yet
+func trackLifecycle(a Actor, initial LifecycleState) *lifecycle {
+	l := &lifecycle{}
+	l.state.Store(int32(initial))
+	if existing, loaded := lifecycles.LoadOrStore(a, l); loaded {
+		return existing.(*lifecycle)
+	}
+	return l
+}
+
+// adoptLifecycle returns the lifecycle of an actor being added to a manager, which starts out Spawned (and adopted)
+// if it isn't tracked yet
+func adoptLifecycle(a Actor) *lifecycle {
+	l := &lifecycle{
+		adopted: true,
+	}
+	l.state.Store(int32(StateSpawned))
+	if existing, loaded := lifecycles.LoadOrStore(a, l); loaded {
+		return existing.(*lifecycle)
+	}
+	return l
+}
+
+// abandonLifecycle returns an actor that could not be added to its manager to Spawned, as if AddActor() had failed
+// adopted actors are no longer tracked - they'll be adopted again if they're re-added
+func abandonLifecycle(a Actor, l *lifecycle) {
+	l.state.Store(int32(StateSpawned))
+	if l.adopted {
+		lifecycles.CompareAndDelete(a, l)
+	}
+}
+
+func lookupLifecycle(a Actor) (*lifecycle, bool) {
+	l, found := lifecycles.Load(a)
+	if !found {
+		return nil, false
+	}
+	return l.(*lifecycle), true
+}
+
+func untrackLifecycle(a Actor) {
+	lifecycles.Delete(a)
+}
+
+// State returns the lifecycle state of the actor provided
+func State(a Actor) LifecycleState {
+	if l, found := lookupLifecycle(a); found {
+		return l.load()
+	}
+	return StateUnknown
+}
+
+// State returns the lifecycle state of the actor provided
+// this is the same as the package-level State() function, as actors may move between managers
+func (m *Manager) State(a Actor) LifecycleState {
+	return State(a)
+}
+
+// ForgetActor stops tracking an actor without calling any of its lifecycle functions, taking it out of its pool if it's
+// idle in one. It's for actors that are being dropped without being destroyed - those of a manager that was never
+// stopped, for instance - and must not be called on an actor that is still in a running manager
+func ForgetActor(a Actor) {
+	l, found := lookupLifecycle(a)
+	if !found {
+		return
+	}
+
+	if l.pool != nil {
+		l.pool.take(a)
+	}
+	lifecycles.CompareAndDelete(a, l)
+}
+
+// DestroyActor destroys an actor that is not in a manager - one that was spawned but never added, or whose deferred
+// spawn was never finished - calling BeginDestroy() and FinishDestroy() on it so that it is no longer tracked
+// pooled actors are returned to their pool instead, while idle pooled actors are taken out of their pool and destroyed
+// Actors in a manager are destroyed via RemoveActor()
+func DestroyActor(a Actor) error {
+	l, found := lookupLifecycle(a)
+	if !found {
+		return &LifecycleError{
+			Op:    "DestroyActor",
+			State: StateUnknown,
+		}
+	}
+
+	// taken out of the pool first, so that it isn't handed out by PooledSpawn() while it's being destroyed
+	idle := l.pool != nil && l.pool.take(a)
+
+	if err := l.transition("DestroyActor", StatePendingKill, StatePostSpawnInitialized, StateSpawned); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var (
+		recycled bool
+		err      error
+	)
+	if idle {
+		// it was already released into its pool once, so it's destroyed outright
+		err = discardActor(ctx, a, l, 0, beginDestroy(ctx, a, 0))
+	} else {
+		recycled, err = destroyActor(ctx, a, l, 0)
+	}
+	kind := EventDestroyed
+	if recycled {
+		kind = EventRecycled
+	}
+	notifyLifecycle(LifecycleEvent{
+		Kind:  kind,
+		Actor: a,
+		Err:   err,
+	})
+	return err
+}
+
+// destroyActor calls BeginDestroy() and FinishDestroy() on an actor that has ended play, then stops tracking it
+// pooled actors have Reset() called instead of FinishDestroy() and are returned to their pool, if it has room
+func destroyActor(ctx context.Context, a Actor, l *lifecycle, timeout time.Duration) (bool, error) {
+	err := beginDestroy(ctx, a, timeout)
+	if err == nil && l.pool != nil {
+		recycled, rerr := l.pool.release(ctx, a, l, timeout)
+		if recycled {
+			return true, nil
+		}
+		err = rerr
+	}
+
+	return false, discardActor(ctx, a, l, timeout, err)
+}
+
+// discardActor calls FinishDestroy() on an actor that has begun being destroyed, then stops tracking it
+// the error provided (from BeginDestroy(), for instance) is returned in preference to FinishDestroy()'s
+func discardActor(ctx context.Context, a Actor, l *lifecycle, timeout time.Duration, err error) error {
+	if ferr := finishDestroy(ctx, a, timeout); err == nil {
+		err = ferr
+	}
+
+	l.state.Store(int32(StateDestroyed))
+	untrackLifecycle(a)
+	return err
+}
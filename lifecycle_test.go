@@ -0,0 +1,195 @@
+package actor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type lifecycleActorTest struct {
+	endPlayTickErr   error
+	endPlayState     actor.LifecycleState
+	hitBeginDestroy  bool
+	hitFinishDestroy bool
+}
+
+func (a *lifecycleActorTest) EndPlay(endPlayReason error) error {
+	a.endPlayState = actor.State(a)
+	a.endPlayTickErr = actor.Tick(a, time.Millisecond)
+	return nil
+}
+
+func (a *lifecycleActorTest) BeginDestroy() error {
+	a.hitBeginDestroy = true
+	return nil
+}
+
+func (a *lifecycleActorTest) FinishDestroy() error {
+	a.hitFinishDestroy = true
+	return nil
+}
+
+func expectLifecycleError(t *testing.T, err error, state actor.LifecycleState) {
+	t.Helper()
+
+	var lerr *actor.LifecycleError
+	if !errors.As(err, &lerr) || lerr.State != state || !errors.Is(err, actor.ErrInvalidLifecycleState) {
+		t.Fatalf("expected a LifecycleError while %v, got %v", state, err)
+	}
+}
+
+func TestActorLifecycle(t *testing.T) {
+	m := startTestManager(t)
+
+	opts := []actor.SpawnActorOption{
+		actor.DeferredSpawnActor(),
+	}
+
+	act, err := actor.SpawnActor(reflect.TypeOf(lifecycleActorTest{}), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := act.(*lifecycleActorTest)
+
+	if state := m.State(a); state != actor.StatePostSpawnInitialized {
+		t.Fatalf("expected PostSpawnInitialized, got %v", state)
+	}
+
+	expectLifecycleError(t, m.AddActor(a), actor.StatePostSpawnInitialized)
+
+	if err := actor.FinishSpawningActor(a, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if state := m.State(a); state != actor.StateSpawned {
+		t.Fatalf("expected Spawned, got %v", state)
+	}
+
+	expectLifecycleError(t, actor.FinishSpawningActor(a, opts...), actor.StateSpawned)
+
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if state := m.State(a); state != actor.StatePlaying {
+		t.Fatalf("expected Playing, got %v", state)
+	}
+
+	other := actor.NewManager()
+	defer other.Stop()
+	expectLifecycleError(t, other.AddActor(a), actor.StatePlaying)
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.endPlayState != actor.StateEndingPlay {
+		t.Fatalf("expected EndingPlay during EndPlay, got %v", a.endPlayState)
+	}
+	expectLifecycleError(t, a.endPlayTickErr, actor.StateEndingPlay)
+
+	if !a.hitBeginDestroy || !a.hitFinishDestroy {
+		t.Fatal("actor not destroyed")
+	}
+
+	// destroyed actors are no longer tracked
+	if state := m.State(a); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+}
+
+type lifecycleFailingActorTest struct {
+	lifecycleActorTest
+}
+
+func (a *lifecycleFailingActorTest) BeginPlay() error {
+	return errors.New("begin play failed")
+}
+
+func TestDestroyActor(t *testing.T) {
+	m := startTestManager(t)
+
+	// a deferred spawn that is never finished
+	act, err := actor.SpawnActor(reflect.TypeOf(lifecycleActorTest{}), actor.DeferredSpawnActor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := actor.DestroyActor(act); err != nil {
+		t.Fatal(err)
+	}
+	if a := act.(*lifecycleActorTest); !a.hitBeginDestroy || !a.hitFinishDestroy {
+		t.Fatal("actor not destroyed")
+	}
+	if state := actor.State(act); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+	expectLifecycleError(t, actor.DestroyActor(act), actor.StateUnknown)
+
+	// a spawned actor that fails to begin play is left to its owner
+	act, err = actor.SpawnActor(reflect.TypeOf(lifecycleFailingActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddActor(act, actor.TickInterval(time.Hour)); err == nil {
+		t.Fatal("expected BeginPlay to fail")
+	}
+	if state := actor.State(act); state != actor.StateSpawned {
+		t.Fatalf("expected Spawned, got %v", state)
+	}
+	if err := actor.DestroyActor(act); err != nil {
+		t.Fatal(err)
+	}
+	if state := actor.State(act); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+
+	// an actor that wasn't spawned via SpawnActor() is only tracked while it's in the manager
+	plain := &lifecycleFailingActorTest{}
+	if err := m.AddActor(plain, actor.TickInterval(time.Hour)); err == nil {
+		t.Fatal("expected BeginPlay to fail")
+	}
+	if state := actor.State(plain); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+
+	playing := &lifecycleActorTest{}
+	if err := m.AddActor(playing, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	expectLifecycleError(t, actor.DestroyActor(playing), actor.StatePlaying)
+}
+
+func TestForgetActor(t *testing.T) {
+	act, err := actor.SpawnActor(reflect.TypeOf(lifecycleActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actor.ForgetActor(act)
+	if a := act.(*lifecycleActorTest); a.hitBeginDestroy || a.hitFinishDestroy {
+		t.Fatal("expected no lifecycle functions to be called")
+	}
+	if state := actor.State(act); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+
+	// the actors of a manager that is dropped without being stopped
+	m := startTestManager(t)
+	playing := &lifecycleActorTest{}
+	if err := m.AddActor(playing, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	m.Stop()
+	if state := actor.State(playing); state != actor.StateUnknown {
+		t.Fatalf("expected stopping the manager to untrack its actors, got %v", state)
+	}
+
+	abandoned := &lifecycleActorTest{}
+	if err := actor.NewManager().AddActor(abandoned, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	actor.ForgetActor(abandoned)
+	if state := actor.State(abandoned); state != actor.StateUnknown {
+		t.Fatalf("expected Unknown, got %v", state)
+	}
+}
@@ -0,0 +1,156 @@
+package actor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidCallTimeout is for when a negative lifecycle call timeout is provided
+var ErrInvalidCallTimeout = errors.New("invalid call timeout")
+
+// The context-aware lifecycle interfaces below are called instead of their plain counterparts when an actor implements
+// both. The context passed to them is cancelled when the work they're part of is abandoned - e.g.: when the manager
+// stops - and carries a deadline if a timeout was set for the call (see: SpawnCallTimeout() and LifecycleTimeouts)
+// the deadline is only advisory: it's up to the actor to give up once its context is done
+
+// PostSpawnInitializeCtxIntf is the context-aware version of PostSpawnInitializeIntf
+type PostSpawnInitializeCtxIntf interface {
+	PostSpawnInitializeCtx(ctx context.Context) error
+}
+
+// ExecuteConstructionCtxIntf is the context-aware version of ExecuteConstructionIntf
+type ExecuteConstructionCtxIntf interface {
+	ExecuteConstructionCtx(ctx context.Context) error
+}
+
+// OnConstructionCtxIntf is the context-aware version of OnConstructionIntf
+type OnConstructionCtxIntf interface {
+	OnConstructionCtx(ctx context.Context) error
+}
+
+// PostActorConstructionCtxIntf is the context-aware version of PostActorConstructionIntf
+type PostActorConstructionCtxIntf interface {
+	PostActorConstructionCtx(ctx context.Context) error
+}
+
+// PreInitializeComponentsCtxIntf is the context-aware version of PreInitializeComponentsIntf
+type PreInitializeComponentsCtxIntf interface {
+	PreInitializeComponentsCtx(ctx context.Context) error
+}
+
+// InitializeComponentsCtxIntf is the context-aware version of InitializeComponentsIntf
+type InitializeComponentsCtxIntf interface {
+	InitializeComponentsCtx(ctx context.Context) error
+}
+
+// PostInitializeComponentsCtxIntf is the context-aware version of PostInitializeComponentsIntf
+type PostInitializeComponentsCtxIntf interface {
+	PostInitializeComponentsCtx(ctx context.Context) error
+}
+
+// OnActorSpawnedCtxIntf is the context-aware version of OnActorSpawnedIntf
+type OnActorSpawnedCtxIntf interface {
+	OnActorSpawnedCtx(ctx context.Context) error
+}
+
+// BeginPlayCtxIntf is the context-aware version of BeginPlayIntf
+// the context is the manager's, which is cancelled when the manager stops
+type BeginPlayCtxIntf interface {
+	BeginPlayCtx(ctx context.Context) error
+}
+
+// TickCtxIntf is the context-aware version of TickIntf
+// the context is the manager's, which is cancelled when the manager stops
+// TickWithContextIntf takes precedence over it when an actor implements both
+type TickCtxIntf interface {
+	TickCtx(ctx context.Context, deltaTime time.Duration) error
+}
+
+// EndPlayCtxIntf is the context-aware version of EndPlayIntf
+// the context is not cancelled by the manager stopping, so that actors may still clean up - bound it with
+// LifecycleTimeouts.EndPlay instead
+type EndPlayCtxIntf interface {
+	EndPlayCtx(ctx context.Context, endPlayReason error) error
+}
+
+// BeginDestroyCtxIntf is the context-aware version of BeginDestroyIntf
+type BeginDestroyCtxIntf interface {
+	BeginDestroyCtx(ctx context.Context) error
+}
+
+// FinishDestroyCtxIntf is the context-aware version of FinishDestroyIntf
+type FinishDestroyCtxIntf interface {
+	FinishDestroyCtx(ctx context.Context) error
+}
+
+// ResetCtxIntf is the context-aware version of ResetIntf
+type ResetCtxIntf interface {
+	ResetCtx(ctx context.Context) error
+}
+
+// SpawnContext sets the context passed to the context-aware spawning calls (PostSpawnInitializeCtx() through
+// OnActorSpawnedCtx()) - context.Background() by default
+func SpawnContext(ctx context.Context) SpawnActorOption {
+	return func(s *spawnActorSettings) error {
+		s.ctx = ctx
+		return nil
+	}
+}
+
+// SpawnCallTimeout sets a deadline on each of the context-aware spawning calls, measured from when each call starts
+// zero (the default) doesn't set one
+func SpawnCallTimeout(timeout time.Duration) SpawnActorOption {
+	return func(s *spawnActorSettings) error {
+		if timeout < 0 {
+			return errors.Wrapf(ErrInvalidCallTimeout, "%v", timeout)
+		}
+
+		s.callTimeout = timeout
+		return nil
+	}
+}
+
+// LifecycleTimeouts set deadlines on the context-aware lifecycle calls a manager makes, measured from when each call
+// starts - zero doesn't set one
+type LifecycleTimeouts struct {
+	BeginPlay time.Duration
+	Tick      time.Duration
+	EndPlay   time.Duration
+	// Destroy covers each of BeginDestroy(), FinishDestroy() and Reset()
+	Destroy time.Duration
+}
+
+// SetLifecycleTimeouts sets the deadlines on the context-aware lifecycle calls the manager makes
+func (m *Manager) SetLifecycleTimeouts(t LifecycleTimeouts) error {
+	for _, d := range []time.Duration{t.BeginPlay, t.Tick, t.EndPlay, t.Destroy} {
+		if d < 0 {
+			return errors.Wrapf(ErrInvalidCallTimeout, "%v", d)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lifecycleTimeouts = t
+	return nil
+}
+
+func noCancel() {}
+
+// withCallTimeout bounds the context by the timeout provided, if it's non-zero
+func withCallTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, noCancel
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// context returns the context the spawning calls are made with
+func (s spawnActorSettings) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
@@ -0,0 +1,160 @@
+package actor_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type ctxKeyTest struct{}
+
+type ctxActorTest struct {
+	initCtx      context.Context
+	beginPlayCtx context.Context
+	tickCh       chan context.Context
+	endPlayCtx   context.Context
+	endPlayErr   error
+	plainCalled  bool
+}
+
+func (a *ctxActorTest) InitializeComponentsCtx(ctx context.Context) error {
+	a.initCtx = ctx
+	return nil
+}
+
+func (a *ctxActorTest) InitializeComponents() error {
+	a.plainCalled = true
+	return nil
+}
+
+func (a *ctxActorTest) BeginPlayCtx(ctx context.Context) error {
+	a.beginPlayCtx = ctx
+	return nil
+}
+
+func (a *ctxActorTest) BeginPlay() error {
+	a.plainCalled = true
+	return nil
+}
+
+func (a *ctxActorTest) TickCtx(ctx context.Context, deltaTime time.Duration) error {
+	select {
+	case a.tickCh <- ctx:
+	default:
+	}
+	return nil
+}
+
+func (a *ctxActorTest) EndPlayCtx(ctx context.Context, endPlayReason error) error {
+	a.endPlayCtx = ctx
+	a.endPlayErr = ctx.Err()
+	return nil
+}
+
+func TestLifecycleContexts(t *testing.T) {
+	if _, err := actor.SpawnActor(reflect.TypeOf(ctxActorTest{}), actor.SpawnCallTimeout(-time.Second)); !errors.Is(err, actor.ErrInvalidCallTimeout) {
+		t.Fatalf("expected ErrInvalidCallTimeout, got %v", err)
+	}
+
+	spawnCtx := context.WithValue(context.Background(), ctxKeyTest{}, "spawn")
+	spawned, err := actor.SpawnActor(reflect.TypeOf(ctxActorTest{}), actor.SpawnContext(spawnCtx), actor.SpawnCallTimeout(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := spawned.(*ctxActorTest)
+	a.tickCh = make(chan context.Context, 1)
+
+	if a.initCtx == nil || a.initCtx.Value(ctxKeyTest{}) != "spawn" {
+		t.Fatal("expected InitializeComponentsCtx to receive the spawn context")
+	}
+	if _, ok := a.initCtx.Deadline(); !ok {
+		t.Fatal("expected InitializeComponentsCtx to receive a deadline")
+	}
+
+	m := actor.NewManager()
+	m.StartTicking(context.Background())
+
+	if err := m.SetLifecycleTimeouts(actor.LifecycleTimeouts{Tick: -time.Second}); !errors.Is(err, actor.ErrInvalidCallTimeout) {
+		t.Fatalf("expected ErrInvalidCallTimeout, got %v", err)
+	}
+	if err := m.SetLifecycleTimeouts(actor.LifecycleTimeouts{
+		Tick:    time.Minute,
+		EndPlay: time.Minute,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddActor(a, actor.TickInterval(10*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if a.plainCalled {
+		t.Fatal("expected the context-aware calls to be preferred")
+	}
+	if a.beginPlayCtx == nil {
+		t.Fatal("expected BeginPlayCtx to be called")
+	}
+	if _, ok := a.beginPlayCtx.Deadline(); ok {
+		t.Fatal("expected BeginPlayCtx to not have a deadline")
+	}
+
+	select {
+	case ctx := <-a.tickCh:
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("expected TickCtx to receive a deadline")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected TickCtx to be called")
+	}
+
+	m.Stop()
+
+	if a.beginPlayCtx.Err() == nil {
+		t.Fatal("expected the manager's context to be cancelled once it stopped")
+	}
+	if a.endPlayCtx == nil || a.endPlayErr != nil {
+		t.Fatalf("expected EndPlayCtx to receive a live context, got %v", a.endPlayErr)
+	}
+	if _, ok := a.endPlayCtx.Deadline(); !ok {
+		t.Fatal("expected EndPlayCtx to receive a deadline")
+	}
+}
+
+type blockingBeginPlayActorTest struct {
+	started chan struct{}
+}
+
+func (a *blockingBeginPlayActorTest) BeginPlayCtx(ctx context.Context) error {
+	close(a.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBeginPlayCancelledOnStop(t *testing.T) {
+	m := actor.NewManager()
+	m.StartTicking(context.Background())
+
+	a := &blockingBeginPlayActorTest{
+		started: make(chan struct{}),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.AddActor(a)
+	}()
+
+	<-a.started
+	m.Stop()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected BeginPlayCtx to be cancelled when the manager stopped")
+	}
+}
@@ -0,0 +1,222 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrActorLoadTimeout is for when an actor doesn't finish its asynchronous BeginPlay within its LoadTimeout()
+	ErrActorLoadTimeout = errors.New("actor load timed out")
+
+	// ErrActorLoadAborted is for when an actor is removed (or its manager stopped) before it finishes loading
+	ErrActorLoadAborted = errors.New("actor load aborted")
+
+	// ErrInvalidLoadTimeout is for when a negative load timeout is provided
+	ErrInvalidLoadTimeout = errors.New("invalid load timeout")
+)
+
+// DefaultLoadTimeout is the default amount of time an actor may take to finish its asynchronous BeginPlay
+const DefaultLoadTimeout = time.Second * 30
+
+// LoadFailurePolicy is what a manager does with an actor that fails to finish its asynchronous BeginPlay
+type LoadFailurePolicy int
+
+const (
+	// LoadFailureRemove removes the actor from the manager, leaving it Spawned - as if AddActor() had failed
+	LoadFailureRemove = LoadFailurePolicy(iota)
+	// LoadFailureDestroy ends play for the actor and destroys it, as if it had been removed via RemoveActor()
+	LoadFailureDestroy
+	// LoadFailurePlay logs the failure and starts ticking the actor anyway
+	LoadFailurePlay
+)
+
+func (p LoadFailurePolicy) String() string {
+	switch p {
+	case LoadFailureRemove:
+		return "Remove"
+	case LoadFailureDestroy:
+		return "Destroy"
+	case LoadFailurePlay:
+		return "Play"
+	default:
+		return fmt.Sprintf("LoadFailurePolicy(%d)", int(p))
+	}
+}
+
+// LoadTimeout sets how long the actor may take to finish its asynchronous BeginPlay (see: AsyncBeginPlayIntf)
+// zero doesn't limit it
+func LoadTimeout(timeout time.Duration) Option {
+	return func(s *actorSettings) error {
+		if timeout < 0 {
+			return errors.Wrapf(ErrInvalidLoadTimeout, "%v", timeout)
+		}
+
+		s.loadTimeout = timeout
+		return nil
+	}
+}
+
+// OnLoadFailure sets what the manager does with the actor if it fails to finish its asynchronous BeginPlay
+// (see: AsyncBeginPlayIntf) - LoadFailureRemove by default
+func OnLoadFailure(p LoadFailurePolicy) Option {
+	return func(s *actorSettings) error {
+		s.loadFailure = p
+		return nil
+	}
+}
+
+// loadState tracks an actor that is loading (or has loaded) via AsyncBeginPlayIntf
+type loadState struct {
+	// ctx is the context passed to BeginPlayAsync(), which is cancelled once the load completes
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+	once   sync.Once
+	err    error
+}
+
+// complete records the outcome of the load - only the first outcome counts
+func (ld *loadState) complete(err error) {
+	ld.once.Do(func() {
+		ld.err = err
+		ld.cancel()
+		close(ld.doneCh)
+	})
+}
+
+// startLoading calls the actor's BeginPlayAsync(), returning the load state and the channel its result arrives on
+func (m *Manager) startLoading(a Actor, t AsyncBeginPlayIntf, s actorSettings) (*loadState, <-chan error) {
+	ctx, cancel := withCallTimeout(withActorName(m.context(), s.name), s.loadTimeout)
+	ld := &loadState{
+		ctx:    ctx,
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+	}
+
+	var readyCh <-chan error
+	_ = traceCallCtx(ctx, "BeginPlayAsync", a, func(ctx context.Context) error {
+		readyCh = t.BeginPlayAsync(ctx)
+		return nil
+	})
+	return ld, readyCh
+}
+
+// awaitLoading waits for the actor to finish loading (or to run out of time), then finishes it on the tick goroutine
+// a nil channel is taken to mean the actor is ready right away
+func (m *Manager) awaitLoading(a Actor, ld *loadState, readyCh <-chan error, timeout time.Duration) {
+	var err error
+	if readyCh != nil {
+		select {
+		case err = <-readyCh:
+		case <-ld.ctx.Done():
+			if !errors.Is(ld.ctx.Err(), context.DeadlineExceeded) {
+				// removed while loading, or the manager stopped - whichever it was takes care of the actor
+				return
+			}
+			err = errors.Wrapf(ErrActorLoadTimeout, "after %v", timeout)
+		}
+	}
+
+	m.post(func() {
+		m.finishLoading(a, ld, err)
+	})
+}
+
+// finishLoading moves an actor that has finished loading into its tick group, or applies its LoadFailurePolicy
+// it runs on the manager's tick goroutine
+func (m *Manager) finishLoading(a Actor, ld *loadState, err error) {
+	m.mu.Lock()
+	ami, found := m.actors[a]
+	if !found || ami.loading != ld || ami.lifecycle.load() != StateLoading {
+		// removed while the result was in flight
+		m.mu.Unlock()
+		return
+	}
+	log := m.loggerLocked()
+
+	if err != nil {
+		log.Error("actor failed to load", append(actorLogAttrs(a, ami),
+			slog.Any("error", err),
+			slog.String("policy", ami.settings.loadFailure.String()))...)
+	}
+
+	if err != nil && ami.settings.loadFailure != LoadFailurePlay {
+		_, _ = m.removeActorFromListsLocked(a)
+		m.mu.Unlock()
+
+		m.discardMailbox(a, ami, ErrActorNotFound)
+
+		if m.observingLifecycle() {
+			notifyLifecycle(LifecycleEvent{
+				Kind:    EventBeginPlay,
+				Actor:   a,
+				Manager: m,
+				Err:     err,
+			})
+		}
+
+		if ami.settings.loadFailure == LoadFailureDestroy {
+			ld.complete(err)
+			if derr := m.stopActor(a, ami, err); derr != nil {
+				log.Error("actor failed to end play", append(actorLogAttrs(a, ami), slog.Any("error", derr))...)
+			}
+			return
+		}
+
+		abandonLifecycle(a, ami.lifecycle)
+		ld.complete(err)
+		return
+	}
+
+	if terr := ami.lifecycle.transition("BeginPlay", StatePlaying, StateLoading); terr != nil {
+		m.mu.Unlock()
+		return
+	}
+	ami.tickState.lastTick = m.clock.Now()
+	m.joinTickGroupLocked(a, ami.settings)
+	m.mu.Unlock()
+
+	ld.complete(err)
+	log.Debug("actor loaded", actorLogAttrs(a, ami)...)
+
+	if m.observingLifecycle() {
+		notifyLifecycle(LifecycleEvent{
+			Kind:    EventBeginPlay,
+			Actor:   a,
+			Manager: m,
+			Err:     err,
+		})
+	}
+
+	// deliver the messages that arrived while it was loading
+	if !ami.mailbox.empty() {
+		m.queueMailbox(a, ami.mailbox)
+	}
+}
+
+// WaitReady waits for an actor that is loading (see: AsyncBeginPlayIntf) to finish, returning the error it failed
+// to load with, if any - actors that didn't load asynchronously are ready as soon as they are added
+func (m *Manager) WaitReady(ctx context.Context, a Actor) error {
+	m.mu.RLock()
+	ami, found := m.actors[a]
+	m.mu.RUnlock()
+	if !found {
+		return ErrActorNotFound
+	}
+	if ami.loading == nil {
+		return nil
+	}
+
+	select {
+	case <-ami.loading.doneCh:
+		return ami.loading.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,180 @@
+package actor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type loadingActorTest struct {
+	readyCh  chan error
+	ctxCh    chan context.Context
+	ticks    atomic.Int32
+	received atomic.Int32
+	ended    atomic.Bool
+}
+
+func newLoadingActorTest() *loadingActorTest {
+	return &loadingActorTest{
+		readyCh: make(chan error, 1),
+		ctxCh:   make(chan context.Context, 1),
+	}
+}
+
+// failLater fails the load once the test has had a chance to start waiting on it
+func (a *loadingActorTest) failLater(err error) {
+	time.AfterFunc(10*time.Millisecond, func() {
+		a.readyCh <- err
+	})
+}
+
+func (a *loadingActorTest) BeginPlayAsync(ctx context.Context) <-chan error {
+	a.ctxCh <- ctx
+	return a.readyCh
+}
+
+func (a *loadingActorTest) Tick(deltaTime time.Duration) error {
+	a.ticks.Add(1)
+	return nil
+}
+
+func (a *loadingActorTest) Receive(msg actor.Message) error {
+	a.received.Add(1)
+	return nil
+}
+
+func (a *loadingActorTest) EndPlay(endPlayReason error) error {
+	a.ended.Store(true)
+	return nil
+}
+
+func TestAsyncBeginPlay(t *testing.T) {
+	m := startTestManager(t)
+	ctx := context.Background()
+
+	a := newLoadingActorTest()
+	if err := m.AddActor(a, actor.TickInterval(5*time.Millisecond), actor.Name("loader")); err != nil {
+		t.Fatal(err)
+	}
+
+	if state := m.State(a); state != actor.StateLoading {
+		t.Fatalf("expected the actor to be Loading, got %v", state)
+	}
+	if stats := m.Stats(); len(stats.Actors) != 1 || stats.Actors[0].State != actor.StateLoading || len(stats.TickGroups) != 0 {
+		t.Fatalf("expected a single loading actor outside of any tick group, got %+v", stats)
+	}
+	if _, err := m.ActorByName("loader"); err != nil {
+		t.Fatalf("expected the loading actor to be found by name, got %v", err)
+	}
+	if err := m.Tell(a, "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if n := a.ticks.Load(); n != 0 {
+		t.Fatalf("expected the loading actor not to tick, got %d ticks", n)
+	}
+	if n := a.received.Load(); n != 0 {
+		t.Fatalf("expected messages to be held while loading, got %d", n)
+	}
+
+	a.readyCh <- nil
+	if err := m.WaitReady(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	if state := m.State(a); state != actor.StatePlaying {
+		t.Fatalf("expected the actor to be Playing, got %v", state)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if n := a.ticks.Load(); n == 0 {
+		t.Fatal("expected the actor to tick once loaded")
+	}
+	if n := a.received.Load(); n != 1 {
+		t.Fatalf("expected the held message to be delivered, got %d", n)
+	}
+}
+
+func TestAsyncBeginPlayFailurePolicies(t *testing.T) {
+	m := startTestManager(t)
+	ctx := context.Background()
+
+	if err := m.AddActor(newLoadingActorTest(), actor.LoadTimeout(-time.Second)); !errors.Is(err, actor.ErrInvalidLoadTimeout) {
+		t.Fatalf("expected ErrInvalidLoadTimeout, got %v", err)
+	}
+
+	timedOut := newLoadingActorTest()
+	if err := m.AddActor(timedOut, actor.LoadTimeout(20*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WaitReady(ctx, timedOut); !errors.Is(err, actor.ErrActorLoadTimeout) {
+		t.Fatalf("expected ErrActorLoadTimeout, got %v", err)
+	}
+	// it wasn't spawned via SpawnActor(), so it's no longer tracked once it's removed
+	if state := m.State(timedOut); state != actor.StateUnknown {
+		t.Fatalf("expected the actor to be removed and no longer tracked, got %v", state)
+	}
+	if err := m.Tell(timedOut, "hello"); !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+
+	loadErr := errors.New("no assets")
+
+	destroyed := newLoadingActorTest()
+	if err := m.AddActor(destroyed, actor.OnLoadFailure(actor.LoadFailureDestroy)); err != nil {
+		t.Fatal(err)
+	}
+	destroyed.failLater(loadErr)
+	if err := m.WaitReady(ctx, destroyed); !errors.Is(err, loadErr) {
+		t.Fatalf("expected the load error, got %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for m.State(destroyed) != actor.StateUnknown && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if state := m.State(destroyed); state != actor.StateUnknown || !destroyed.ended.Load() {
+		t.Fatalf("expected the actor to have ended play and been destroyed, got %v", state)
+	}
+
+	played := newLoadingActorTest()
+	if err := m.AddActor(played, actor.TickInterval(5*time.Millisecond), actor.OnLoadFailure(actor.LoadFailurePlay)); err != nil {
+		t.Fatal(err)
+	}
+	played.failLater(loadErr)
+	if err := m.WaitReady(ctx, played); !errors.Is(err, loadErr) {
+		t.Fatalf("expected the load error, got %v", err)
+	}
+	if state := m.State(played); state != actor.StatePlaying {
+		t.Fatalf("expected the actor to be Playing anyway, got %v", state)
+	}
+}
+
+func TestRemoveLoadingActor(t *testing.T) {
+	m := startTestManager(t)
+
+	a := newLoadingActorTest()
+	if err := m.AddActor(a); err != nil {
+		t.Fatal(err)
+	}
+	loadCtx := <-a.ctxCh
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-loadCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the load to be cancelled when the actor was removed")
+	}
+	if !a.ended.Load() {
+		t.Fatal("expected EndPlay to be called")
+	}
+	if state := m.State(a); state != actor.StateUnknown {
+		t.Fatalf("expected the actor to be destroyed, got %v", state)
+	}
+}
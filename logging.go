@@ -0,0 +1,68 @@
+package actor
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// SetLogger sets the logger the manager writes its structured records to, or reverts to slog.Default() if nil
+func (m *Manager) SetLogger(l *slog.Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.log = l
+}
+
+func (m *Manager) logger() *slog.Logger {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.loggerLocked()
+}
+
+// loggerLocked is for callers that already hold the manager's lock
+func (m *Manager) loggerLocked() *slog.Logger {
+	if m.log == nil {
+		return slog.Default()
+	}
+	return m.log
+}
+
+// ActorLogger returns the manager's logger, pre-tagged with the actor's ID, type and name
+func (m *Manager) ActorLogger(a Actor) *slog.Logger {
+	m.mu.RLock()
+	ami, found := m.actors[a]
+	m.mu.RUnlock()
+
+	if !found {
+		return m.logger().With(slog.String("actor_type", reflect.TypeOf(a).String()))
+	}
+	return m.logger().With(actorLogAttrs(a, ami)...)
+}
+
+func actorLogAttrs(a Actor, ami actorMgrInfo) []any {
+	attrs := []any{
+		slog.Uint64("actor_id", ami.id),
+		slog.String("actor_type", reflect.TypeOf(a).String()),
+	}
+	if ami.settings.name != "" {
+		attrs = append(attrs, slog.String("actor_name", ami.settings.name))
+	}
+	return attrs
+}
+
+// SpawnLogger sets the logger that SpawnActor/FinishSpawningActor write their structured records to
+// otherwise, slog.Default() is used
+func SpawnLogger(l *slog.Logger) SpawnActorOption {
+	return func(s *spawnActorSettings) error {
+		s.logger = l
+		return nil
+	}
+}
+
+func (s *spawnActorSettings) log() *slog.Logger {
+	if s.logger == nil {
+		return slog.Default()
+	}
+	return s.logger
+}
@@ -0,0 +1,115 @@
+package actor_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type logBufferTest struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBufferTest) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBufferTest) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var errLoggingActorTest = errors.New("logging actor test error")
+
+type loggingActorTest struct{}
+
+func (a *loggingActorTest) Tick(deltaTime time.Duration) error {
+	return errLoggingActorTest
+}
+
+func (a *loggingActorTest) EndPlay(endPlayReason error) error {
+	return errLoggingActorTest
+}
+
+func TestManagerLogging(t *testing.T) {
+	buf := &logBufferTest{}
+	m := actor.NewManager()
+	m.SetLogger(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	m.StartTicking(context.Background())
+
+	if err := m.AddActor(&loggingActorTest{}, actor.TickInterval(0)); !errors.Is(err, actor.ErrTickIntervalCannotBeZero) {
+		t.Fatalf("expected ErrTickIntervalCannotBeZero, got %v", err)
+	}
+
+	a := &loggingActorTest{}
+	if err := m.AddActor(a, actor.TickInterval(time.Millisecond), actor.Name("noisy")); err != nil {
+		t.Fatal(err)
+	}
+
+	m.ActorLogger(a).Info("hello")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buf.String(), `"msg":"actor tick failed"`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("tick error not logged:\n%s", buf)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := m.RemoveActor(a, nil); !errors.Is(err, errLoggingActorTest) {
+		t.Fatalf("expected EndPlay error from RemoveActor, got %v", err)
+	}
+
+	if err := m.AddActor(&loggingActorTest{}, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	m.Stop()
+
+	out := buf.String()
+	for _, expected := range []string{
+		`"msg":"hello","actor_id":1,"actor_type":"*actor_test.loggingActorTest","actor_name":"noisy"`,
+		`"msg":"actor added"`,
+		`"msg":"actor removed"`,
+		`"msg":"manager stopping","actors":1`,
+		`"msg":"actor failed to end play"`,
+		`"msg":"manager stopped"`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected %s in log output:\n%s", expected, out)
+		}
+	}
+}
+
+func TestManagerLoggingQuietByDefault(t *testing.T) {
+	buf := &logBufferTest{}
+	m := actor.NewManager()
+	m.SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+	m.StartTicking(context.Background())
+
+	if err := m.AddActor(&loggingActorTest{}, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	m.Stop()
+
+	// only the failure makes it past the Info level
+	out := buf.String()
+	if !strings.Contains(out, "actor failed to end play") {
+		t.Fatalf("expected the failure to be logged, got:\n%s", out)
+	}
+	for _, unexpected := range []string{"actor added", "manager stopping", "manager stopped"} {
+		if strings.Contains(out, unexpected) {
+			t.Fatalf("expected no %q in log output:\n%s", unexpected, out)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package actor
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrMailboxFull is for when a message is dropped because the target actor's bounded mailbox is full
+	ErrMailboxFull = errors.New("mailbox full")
+
+	// ErrInvalidMailboxCapacity is for when a bounded mailbox is given a capacity of less than one
+	ErrInvalidMailboxCapacity = errors.New("invalid mailbox capacity")
+
+	// ErrInvalidMailboxThroughput is for when a negative mailbox throughput is provided
+	ErrInvalidMailboxThroughput = errors.New("invalid mailbox throughput")
+)
+
+// OverflowPolicy is what a bounded mailbox does with a message sent to it while it's full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the sender wait until there is room in the mailbox
+	// NOTE: the manager's tick goroutine (e.g.: from within Tick()) must not send to a blocking mailbox, as the
+	// mailbox is only emptied by that goroutine
+	OverflowBlock = OverflowPolicy(iota)
+	// OverflowDropNewest drops the message being sent - Tell() returns ErrMailboxFull
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest message waiting in the mailbox to make room for the message being sent
+	// in a priority mailbox, the oldest of its lowest priority messages is dropped - which may be the message being sent, in which case Tell() returns ErrMailboxFull
+	OverflowDropOldest
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "Block"
+	case OverflowDropNewest:
+		return "DropNewest"
+	case OverflowDropOldest:
+		return "DropOldest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// PriorityMessageIntf is for messages that want to be ordered within a priority mailbox (see: PriorityMailbox())
+// messages with a higher priority are delivered first - messages that don't implement it have a priority of zero
+type PriorityMessageIntf interface {
+	MessagePriority() int
+}
+
+// ControlMessageIntf is for messages that jump the queue - they're delivered ahead of any other messages waiting in
+// the mailbox, whatever kind of mailbox it is, and don't count towards (nor get dropped by) a bounded mailbox's capacity
+type ControlMessageIntf interface {
+	ControlMessage()
+}
+
+// MailboxMetricsSink may be implemented by a MetricsSink that wants to be told about mailbox overflows
+type MailboxMetricsSink interface {
+	ObserveMailboxOverflow(a Actor, msg Message, policy OverflowPolicy)
+}
+
+type mailboxSettings struct {
+	// capacity is the maximum number of (non-control) messages the mailbox holds - zero for unbounded
+	capacity   int
+	overflow   OverflowPolicy
+	priority   bool
+	throughput int
+}
+
+// options rebuilds the list of Options that would produce these settings
+func (s mailboxSettings) options() []Option {
+	var opts []Option
+	if s.capacity > 0 {
+		opts = append(opts, BoundedMailbox(s.capacity, s.overflow))
+	}
+	if s.priority {
+		opts = append(opts, PriorityMailbox())
+	}
+	if s.throughput > 0 {
+		opts = append(opts, MailboxThroughput(s.throughput))
+	}
+	return opts
+}
+
+// UnboundedMailbox gives the actor a mailbox that holds any number of messages (the default)
+func UnboundedMailbox() Option {
+	return func(s *actorSettings) error {
+		s.mailbox.capacity = 0
+		return nil
+	}
+}
+
+// BoundedMailbox gives the actor a mailbox that holds at most capacity messages, applying the overflow policy
+// provided to messages sent while it's full
+func BoundedMailbox(capacity int, overflow OverflowPolicy) Option {
+	return func(s *actorSettings) error {
+		if capacity < 1 {
+			return errors.Wrapf(ErrInvalidMailboxCapacity, "%d", capacity)
+		}
+
+		s.mailbox.capacity = capacity
+		s.mailbox.overflow = overflow
+		return nil
+	}
+}
+
+// PriorityMailbox orders the actor's messages by their priority (see: PriorityMessageIntf), rather than the order
+// they were sent in - messages of the same priority are still delivered in the order they were sent
+func PriorityMailbox() Option {
+	return func(s *actorSettings) error {
+		s.mailbox.priority = true
+		return nil
+	}
+}
+
+// MailboxThroughput limits the number of messages the actor handles each time the manager processes its mailboxes,
+// so that a busy actor can't hold up the manager's tick goroutine - the rest wait for the next time around
+// zero (the default) doesn't limit it
+func MailboxThroughput(n int) Option {
+	return func(s *actorSettings) error {
+		if n < 0 {
+			return errors.Wrapf(ErrInvalidMailboxThroughput, "%d", n)
+		}
+
+		s.mailbox.throughput = n
+		return nil
+	}
+}
+
+type mailbox struct {
+	mu       sync.Mutex
+	settings mailboxSettings
+	control  []envelope
+	messages []envelope
+	// notFull is closed (and replaced) whenever messages are taken out, waking senders blocked on a full mailbox
+	notFull chan struct{}
+	closed  bool
+	dropped atomic.Uint64
+}
+
+func newMailbox(s mailboxSettings) *mailbox {
+	return &mailbox{
+		settings: s,
+		notFull:  make(chan struct{}),
+	}
+}
+
+type pushResult struct {
+	// wasEmpty reports if the mailbox was empty beforehand, in which case it needs to be queued for processing
+	wasEmpty bool
+	// dropped is the message dropped to make room (or the message sent itself), if any
+	dropped    envelope
+	hasDropped bool
+	// wait is set when the sender must wait for room in the mailbox before trying again
+	wait <-chan struct{}
+	err  error
+}
+
+// push adds a message to the mailbox, applying its overflow policy if it's full
+func (mb *mailbox) push(env envelope) pushResult {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.closed {
+		return pushResult{
+			err: ErrActorNotFound,
+		}
+	}
+
+	res := pushResult{
+		wasEmpty: len(mb.control) == 0 && len(mb.messages) == 0,
+	}
+
+	if _, ok := env.message().(ControlMessageIntf); ok {
+		mb.control = append(mb.control, env)
+		return res
+	}
+
+	if mb.settings.capacity > 0 && len(mb.messages) >= mb.settings.capacity {
+		switch mb.settings.overflow {
+		case OverflowDropNewest:
+			mb.dropped.Add(1)
+			res.dropped, res.hasDropped = env, true
+			res.err = ErrMailboxFull
+			return res
+		case OverflowDropOldest:
+			victim := 0
+			if mb.settings.priority {
+				// the oldest of the lowest priority messages, which may be the message being sent
+				lowest := messagePriority(mb.messages[len(mb.messages)-1])
+				if messagePriority(env) < lowest {
+					mb.dropped.Add(1)
+					res.dropped, res.hasDropped = env, true
+					res.err = ErrMailboxFull
+					return res
+				}
+				victim = sort.Search(len(mb.messages), func(i int) bool {
+					return messagePriority(mb.messages[i]) <= lowest
+				})
+			}
+			mb.dropped.Add(1)
+			res.dropped, res.hasDropped = mb.messages[victim], true
+			mb.messages = append(mb.messages[:victim], mb.messages[victim+1:]...)
+		default:
+			res.wait = mb.notFull
+			return res
+		}
+	}
+
+	if !mb.settings.priority {
+		mb.messages = append(mb.messages, env)
+		return res
+	}
+
+	// after every message of the same or a higher priority
+	p := messagePriority(env)
+	i := sort.Search(len(mb.messages), func(i int) bool {
+		return messagePriority(mb.messages[i]) < p
+	})
+	mb.messages = append(mb.messages, envelope{})
+	copy(mb.messages[i+1:], mb.messages[i:])
+	mb.messages[i] = env
+	return res
+}
+
+func messagePriority(env envelope) int {
+	if p, ok := env.message().(PriorityMessageIntf); ok {
+		return p.MessagePriority()
+	}
+	return 0
+}
+
+// empty reports if there are no messages in the mailbox
+func (mb *mailbox) empty() bool {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	return len(mb.control) == 0 && len(mb.messages) == 0
+}
+
+// len returns the number of messages waiting in the mailbox
+func (mb *mailbox) len() int {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	return len(mb.control) + len(mb.messages)
+}
+
+// drain removes and returns the messages in the mailbox - control messages first - up to the mailbox's throughput
+// it also reports if there are any messages left behind
+func (mb *mailbox) drain() ([]envelope, bool) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	msgs := mb.control
+	mb.control = nil
+
+	n := len(mb.messages)
+	if limit := mb.settings.throughput; limit > 0 {
+		if limit -= len(msgs); limit < 1 {
+			limit = 1
+		}
+		if n > limit {
+			n = limit
+		}
+	}
+	if n > 0 {
+		msgs = append(msgs, mb.messages[:n]...)
+		mb.messages = append([]envelope(nil), mb.messages[n:]...)
+		mb.wakeSendersLocked()
+	}
+
+	return msgs, len(mb.messages) > 0
+}
+
+// close empties the mailbox and stops it from taking any more messages, returning the messages that were in it
+func (mb *mailbox) close() []envelope {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	msgs := append(mb.control, mb.messages...)
+	mb.control = nil
+	mb.messages = nil
+	if !mb.closed {
+		mb.closed = true
+		mb.wakeSendersLocked()
+	}
+	return msgs
+}
+
+func (mb *mailbox) wakeSendersLocked() {
+	close(mb.notFull)
+	mb.notFull = make(chan struct{})
+}
+
+// reportOverflow records a message dropped by a full mailbox
+func (m *Manager) reportOverflow(a Actor, ami actorMgrInfo, env envelope) {
+	m.mu.RLock()
+	sink := m.metricsSink
+	log := m.loggerLocked()
+	m.mu.RUnlock()
+
+	policy := ami.mailbox.settings.overflow
+	log.Debug("actor mailbox overflowed", append(actorLogAttrs(a, ami), slog.String("policy", policy.String()))...)
+
+	if ms, ok := sink.(MailboxMetricsSink); ok {
+		ms.ObserveMailboxOverflow(a, env.message(), policy)
+	}
+
+	m.undeliverable(a, []envelope{env}, ErrMailboxFull)
+}
@@ -0,0 +1,261 @@
+package actor_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type mailboxHoldTest struct{}
+
+type mailboxPriorityTest struct {
+	name     string
+	priority int
+}
+
+func (m mailboxPriorityTest) MessagePriority() int {
+	return m.priority
+}
+
+type mailboxControlTest struct{}
+
+func (mailboxControlTest) ControlMessage() {}
+
+type mailboxActorTest struct {
+	started  chan struct{}
+	gate     chan struct{}
+	received chan actor.Message
+}
+
+func newMailboxActorTest() *mailboxActorTest {
+	return &mailboxActorTest{
+		started:  make(chan struct{}),
+		gate:     make(chan struct{}),
+		received: make(chan actor.Message, 16),
+	}
+}
+
+func (a *mailboxActorTest) Receive(msg actor.Message) error {
+	if _, ok := msg.(mailboxHoldTest); ok {
+		close(a.started)
+		<-a.gate
+		return nil
+	}
+
+	a.received <- msg
+	return nil
+}
+
+// hold blocks the manager's tick goroutine in the actor's Receive(), so that messages pile up in its mailbox
+func (a *mailboxActorTest) hold(t *testing.T, m *actor.Manager) {
+	t.Helper()
+
+	if err := m.Tell(a, mailboxHoldTest{}); err != nil {
+		t.Fatal(err)
+	}
+	<-a.started
+}
+
+func (a *mailboxActorTest) expect(t *testing.T, msgs ...actor.Message) {
+	t.Helper()
+
+	for i, want := range msgs {
+		select {
+		case got := <-a.received:
+			if got != want {
+				t.Fatalf("message %d: expected %#v, got %#v", i, want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message %d: expected %#v, got nothing", i, want)
+		}
+	}
+}
+
+func startMailboxTest(t *testing.T, a actor.Actor, opts ...actor.Option) *actor.Manager {
+	t.Helper()
+
+	m := startTestManager(t)
+
+	if err := m.AddActor(a, append([]actor.Option{actor.TickInterval(time.Hour)}, opts...)...); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestPriorityMailbox(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.PriorityMailbox())
+	a.hold(t, m)
+
+	low := mailboxPriorityTest{"low", 1}
+	high := mailboxPriorityTest{"high", 5}
+	high2 := mailboxPriorityTest{"high2", 5}
+	for _, msg := range []actor.Message{"plain", low, high, mailboxControlTest{}, high2} {
+		if err := m.Tell(a, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(a.gate)
+	a.expect(t, mailboxControlTest{}, high, high2, low, "plain")
+}
+
+func TestBoundedMailboxDropNewest(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.BoundedMailbox(2, actor.OverflowDropNewest))
+
+	var mu sync.Mutex
+	var dead []actor.DeadLetter
+	defer m.AddDeadLetterObserver(func(dl actor.DeadLetter) {
+		mu.Lock()
+		dead = append(dead, dl)
+		mu.Unlock()
+	})()
+
+	a.hold(t, m)
+
+	for _, msg := range []actor.Message{1, 2} {
+		if err := m.Tell(a, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.Tell(a, 3); !errors.Is(err, actor.ErrMailboxFull) {
+		t.Fatalf("expected ErrMailboxFull, got %v", err)
+	}
+	// control messages don't count towards the capacity
+	if err := m.Tell(a, mailboxControlTest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := m.Stats().Actors[0]
+	if stats.MailboxLen != 3 || stats.MailboxDropped != 1 {
+		t.Fatalf("expected 3 waiting and 1 dropped, got %d and %d", stats.MailboxLen, stats.MailboxDropped)
+	}
+
+	mu.Lock()
+	if len(dead) != 1 || dead[0].Message != 3 || dead[0].Target != a || !errors.Is(dead[0].Reason, actor.ErrMailboxFull) {
+		t.Fatalf("expected message 3 to be a dead letter, got %+v", dead)
+	}
+	mu.Unlock()
+
+	close(a.gate)
+	a.expect(t, mailboxControlTest{}, 1, 2)
+}
+
+func TestBoundedMailboxDropOldest(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.BoundedMailbox(2, actor.OverflowDropOldest))
+	a.hold(t, m)
+
+	for _, msg := range []actor.Message{1, 2, 3} {
+		if err := m.Tell(a, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(a.gate)
+	a.expect(t, 2, 3)
+}
+
+func TestBoundedPriorityMailboxDropOldest(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.BoundedMailbox(2, actor.OverflowDropOldest), actor.PriorityMailbox())
+	a.hold(t, m)
+
+	low := mailboxPriorityTest{"low", 1}
+	low2 := mailboxPriorityTest{"low2", 1}
+	high := mailboxPriorityTest{"high", 5}
+	lowest := mailboxPriorityTest{"lowest", 0}
+	for _, msg := range []actor.Message{low, low2, high} {
+		if err := m.Tell(a, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the message being sent is the lowest priority, so it's the one dropped
+	if err := m.Tell(a, lowest); !errors.Is(err, actor.ErrMailboxFull) {
+		t.Fatalf("expected ErrMailboxFull, got %v", err)
+	}
+
+	close(a.gate)
+	a.expect(t, high, low2)
+}
+
+func TestBoundedMailboxBlock(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.BoundedMailbox(1, actor.OverflowBlock))
+	a.hold(t, m)
+
+	if err := m.Tell(a, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- m.Tell(a, 2)
+	}()
+
+	select {
+	case err := <-sent:
+		t.Fatalf("expected Tell to block on a full mailbox, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(a.gate)
+	if err := <-sent; err != nil {
+		t.Fatal(err)
+	}
+	a.expect(t, 1, 2)
+}
+
+func TestBoundedMailboxBlockRemoved(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.BoundedMailbox(1, actor.OverflowBlock))
+	a.hold(t, m)
+	defer close(a.gate)
+
+	if err := m.Tell(a, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- m.Tell(a, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-sent; !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+}
+
+func TestMailboxThroughput(t *testing.T) {
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.MailboxThroughput(1))
+	a.hold(t, m)
+
+	for _, msg := range []actor.Message{1, 2, 3} {
+		if err := m.Tell(a, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	close(a.gate)
+	a.expect(t, 1, 2, 3)
+}
+
+func TestInvalidMailboxOptions(t *testing.T) {
+	m := actor.NewManager()
+
+	if err := m.AddActor(newMailboxActorTest(), actor.BoundedMailbox(0, actor.OverflowBlock)); !errors.Is(err, actor.ErrInvalidMailboxCapacity) {
+		t.Fatalf("expected ErrInvalidMailboxCapacity, got %v", err)
+	}
+	if err := m.AddActor(newMailboxActorTest(), actor.MailboxThroughput(-1)); !errors.Is(err, actor.ErrInvalidMailboxThroughput) {
+		t.Fatalf("expected ErrInvalidMailboxThroughput, got %v", err)
+	}
+}
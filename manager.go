@@ -2,8 +2,14 @@ package actor
 
 import (
 	"context"
-	"reflect"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"runtime/trace"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,6 +28,12 @@ var (
 	// ErrTickIntervalCannotBeZero is for when someone tries to pass a zero value into the tick interval
 	// ... that's a special case: see TickEveryFrame()
 	ErrTickIntervalCannotBeZero = errors.New("tick interval cannot be zero")
+
+	// ErrActorNameTaken is for when an actor is added with a name already used by another actor in the manager
+	ErrActorNameTaken = errors.New("actor name already taken")
+
+	// ErrInvalidTickPhase is for when a negative tick phase or jitter is provided
+	ErrInvalidTickPhase = errors.New("invalid tick phase")
 )
 
 // DefaultTickInterval is the default tick interval for actors
@@ -29,6 +41,114 @@ const DefaultTickInterval = time.Millisecond * 200
 
 type actorSettings struct {
 	tickInterval time.Duration
+	tickPhase    time.Duration
+	jitterMax    time.Duration
+	jitterStep   time.Duration
+	name         string
+	tags         []string
+
+	// accumulateSkipped keeps the time that passes while WantTick() skips the actor, for its next tick
+	accumulateSkipped bool
+
+	loadTimeout time.Duration
+	loadFailure LoadFailurePolicy
+
+	// calendar is set for actors that tick on a wall-clock schedule (see: TickCron(), TickAt()), in which case the
+	// tick interval is zero
+	calendar tickCalendar
+
+	mailbox mailboxSettings
+}
+
+// resolvePhase validates the tick phase and jitter, then picks the actor's phase within its tick interval
+func (s *actorSettings) resolvePhase() error {
+	if s.tickPhase < 0 {
+		return errors.Wrapf(ErrInvalidTickPhase, "phase %v", s.tickPhase)
+	}
+	if s.jitterMax < 0 || s.jitterStep < 0 {
+		return errors.Wrapf(ErrInvalidTickPhase, "jitter %v (step %v)", s.jitterMax, s.jitterStep)
+	}
+
+	if s.tickInterval == 0 {
+		// Every-Frame actors tick whenever the frame does
+		s.tickPhase = 0
+		return nil
+	}
+
+	phase := s.tickPhase
+	if s.jitterMax > 0 {
+		jitter := time.Duration(rand.Int63n(int64(s.jitterMax)))
+		if s.jitterStep > 0 {
+			jitter -= jitter % s.jitterStep
+		}
+		phase += jitter
+	}
+	s.tickPhase = phase % s.tickInterval
+	s.jitterMax = 0
+	return nil
+}
+
+// tickGroupKey identifies a tick group - the actors sharing a tick interval are split up by their phase within it
+// actors on a wall-clock schedule are grouped by their schedule instead
+type tickGroupKey struct {
+	interval time.Duration
+	phase    time.Duration
+	calendar string
+}
+
+func (s actorSettings) tickGroupKey() tickGroupKey {
+	key := tickGroupKey{
+		interval: s.tickInterval,
+		phase:    s.tickPhase,
+	}
+	if s.calendar != nil {
+		key.calendar = s.calendar.String()
+	}
+	return key
+}
+
+// scheduled reports if the tick group ticks on its own - only the Every-Frame group doesn't (see: Manager.TickFrame())
+func (k tickGroupKey) scheduled() bool {
+	return k.interval != 0 || k.calendar != ""
+}
+
+// options rebuilds the list of Options that would produce these settings
+func (s actorSettings) options() []Option {
+	var opts []Option
+	if s.calendar != nil {
+		opts = append(opts, calendarOption(s.calendar))
+	} else if s.tickInterval == time.Duration(0) {
+		opts = append(opts, TickEveryFrame())
+	} else {
+		opts = append(opts, TickInterval(s.tickInterval))
+		if s.tickPhase != 0 {
+			opts = append(opts, TickPhase(s.tickPhase))
+		}
+	}
+
+	if s.accumulateSkipped {
+		opts = append(opts, AccumulateSkippedTime())
+	}
+
+	if s.loadTimeout != DefaultLoadTimeout {
+		opts = append(opts, LoadTimeout(s.loadTimeout))
+	}
+
+	if s.loadFailure != LoadFailureRemove {
+		opts = append(opts, OnLoadFailure(s.loadFailure))
+	}
+
+	opts = append(opts, s.mailbox.options()...)
+
+	if s.name != "" {
+		opts = append(opts, Name(s.name))
+	}
+
+	if len(s.tags) > 0 {
+		opts = append(opts, Tags(s.tags...))
+	}
+
+	return opts
 }
 
 // Option is a function that sets up an option during the AddActor function
@@ -42,6 +162,7 @@ func TickInterval(interval time.Duration) Option {
 		}
 
 		s.tickInterval = interval
+		s.calendar = nil
 		return nil
 	}
 }
@@ -51,30 +172,186 @@ func TickInterval(interval time.Duration) Option {
 func TickEveryFrame() Option {
 	return func(s *actorSettings) error {
 		s.tickInterval = time.Duration(0) // special Every-Frame interval
+		s.calendar = nil
+		return nil
+	}
+}
+
+// TickPhase offsets the actor's ticks within its tick interval, so that actors sharing an interval don't all tick at once
+// e.g.: actors at a 1s interval with phases of 0, 100ms, 200ms, etc. tick in separate batches every 100ms
+// phases are measured from when the manager was created, and wrap around at the tick interval
+func TickPhase(offset time.Duration) Option {
+	return func(s *actorSettings) error {
+		s.tickPhase = offset
+		return nil
+	}
+}
+
+// TickJitter adds a random offset in [0, max) to the actor's tick phase (see: TickPhase()), picked when it's added
+// the offset is rounded down to a multiple of step (if non-zero), which bounds the number of separate batches -
+// e.g.: TickJitter(time.Second, 100*time.Millisecond) spreads actors at a 1s interval across 10 batches
+func TickJitter(max time.Duration, step time.Duration) Option {
+	return func(s *actorSettings) error {
+		s.jitterMax = max
+		s.jitterStep = step
+		return nil
+	}
+}
+
+// AccumulateSkippedTime has the actor's deltaTime include the ticks it skipped via WantTick(), once it ticks again
+// by default, skipped ticks are dropped and the actor only receives the time since the tick it last skipped
+func AccumulateSkippedTime() Option {
+	return func(s *actorSettings) error {
+		s.accumulateSkipped = true
+		return nil
+	}
+}
+
+// Name sets a name for the actor that is unique within the manager, which allows it to be found
+// via ActorByName() or referenced remotely (see: Node)
+func Name(name string) Option {
+	return func(s *actorSettings) error {
+		s.name = name
+		return nil
+	}
+}
+
+// Tags attaches a set of tags to the actor, which are preserved by Snapshot/Restore
+func Tags(tags ...string) Option {
+	return func(s *actorSettings) error {
+		s.tags = append(s.tags, tags...)
 		return nil
 	}
 }
 
 type actorList struct {
 	list     map[Actor]struct{}
+	interval time.Duration
+	phase    time.Duration
+	calendar tickCalendar
+	// lastTick is when the group last ticked, which its lateness is measured from - actors keep their own
 	lastTick time.Time
+	stats    tickGroupStats
+
+	// nextDue and heapIndex place interval tick groups in the manager's schedule
+	nextDue   time.Time
+	heapIndex int
+
+	// budgetCursor is the ID of the actor to tick first when the group has a TickBudget that defers actors
+	budgetCursor uint64
 }
 
 type actorMgrInfo struct {
-	tickGroup *time.Ticker
+	id        uint64
+	settings  actorSettings
+	mailbox   *mailbox
+	stats     *tickStats
+	tickState *actorTickState
+	lifecycle *lifecycle
+	// loading is set for actors that began play asynchronously (see: AsyncBeginPlayIntf)
+	loading *loadState
+}
+
+type frameRequest struct {
+	// doneCh receives the frame's error once it has finished ticking, if set
+	doneCh chan error
+}
+
+// FrameError is for when one or more Every-Frame actors fail to tick during a frame requested via TickFrameSync()
+type FrameError struct {
+	Frame  uint64
+	Errors []error
+}
+
+func (e *FrameError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("frame %d: %s", e.Frame, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the actors that failed to tick, so that errors.Is() and errors.As() can match them
+func (e *FrameError) Unwrap() []error {
+	return e.Errors
+}
+
+// BatchError is for when one or more of the actors in a call to AddActors() or RemoveActors() fail
+type BatchError struct {
+	// Errors holds the error of each actor in the batch, in the same order - nil for those that succeeded
+	Errors []error
+}
+
+// newBatchError returns a *BatchError for the errors provided, or nil if they're all nil
+func newBatchError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return &BatchError{
+				Errors: errs,
+			}
+		}
+	}
+	return nil
+}
+
+func (e *BatchError) Error() string {
+	var msgs []string
+	for i, err := range e.Errors {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("actor %d: %v", i, err))
+		}
+	}
+	return fmt.Sprintf("%d of %d actors failed: %s", len(msgs), len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the actors that failed, so that errors.Is() and errors.As() can match them
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
 }
 
 // Manager manages actors - and isn't paid enough to deal with their crap
 type Manager struct {
 	mu                  sync.RWMutex
 	actors              map[Actor]actorMgrInfo
-	tickGroups          map[*time.Ticker]*actorList
-	tickGroupTickers    map[time.Duration]*time.Ticker
+	names               map[string]Actor
+	tickGroups          map[tickGroupKey]*actorList
+	schedule            tickSchedule
+	clock               Clock
+	clockGen            uint64
+	epoch               time.Time
 	tickGroupsUpdatedCh chan struct{}
 	tickStoppedCh       chan struct{}
-	tickFrameCh         chan struct{}
-	stopping            bool
-
+	tickFrameCh         chan *frameRequest
+	frameCompletedCh    chan uint64
+	frames              atomic.Uint64
+	mailboxCh           chan struct{}
+	pendingMu           sync.Mutex
+	pendingMailboxes    []pendingMailbox
+	pendingTasks        []func()
+	tickGroupHooks      []func(actors []Actor)
+	removedHooks        []func(a Actor, id uint64)
+	statsMu             sync.Mutex
+	metricsSink         MetricsSink
+	log                 *slog.Logger
+	tickBudgets         map[time.Duration]*tickBudgetState
+	significance        *significanceState
+	observers           observerList
+	deadLetters         deadLetterList
+	lifecycleTimeouts   LifecycleTimeouts
+	timeDilation        atomic.Uint64
+	stopping            atomic.Bool
+	nextID              uint64
+
+	// stopped is set (under mu) once Stop() has torn down the tick groups - nothing may join them after that
+	stopped bool
+
+	ctx        context.Context
 	cancelFunc context.CancelFunc
 }
 
@@ -82,12 +359,18 @@ type Manager struct {
 func NewManager() *Manager {
 	m := Manager{
 		actors:              make(map[Actor]actorMgrInfo),
-		tickGroups:          make(map[*time.Ticker]*actorList),
-		tickGroupTickers:    make(map[time.Duration]*time.Ticker),
+		names:               make(map[string]Actor),
+		tickGroups:          make(map[tickGroupKey]*actorList),
+		clock:               systemClock{},
 		tickGroupsUpdatedCh: make(chan struct{}, 1),
 		tickStoppedCh:       make(chan struct{}, 1),
-		tickFrameCh:         make(chan struct{}, 1),
+		tickFrameCh:         make(chan *frameRequest, 1),
+		frameCompletedCh:    make(chan uint64, 1),
+		mailboxCh:           make(chan struct{}, 1),
+		tickBudgets:         make(map[time.Duration]*tickBudgetState),
 	}
+	m.timeDilation.Store(math.Float64bits(1))
+	m.epoch = m.clock.Now()
 
 	return &m
 }
@@ -95,17 +378,11 @@ func NewManager() *Manager {
 // Stop stops all actors ticks and shuts down the the manager,
 // effectively rendering it useless
 func (m *Manager) Stop() {
-	if m.stopping {
+	if !m.stopping.CompareAndSwap(false, true) {
 		return
 	}
 
-	m.stopping = true
-
-	defer func() {
-		close(m.tickGroupsUpdatedCh)
-		close(m.tickStoppedCh)
-		close(m.tickFrameCh)
-	}()
+	defer close(m.tickStoppedCh)
 
 	if m.cancelFunc != nil {
 		m.cancelFunc()
@@ -114,243 +391,725 @@ func (m *Manager) Stop() {
 	}
 
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	m.stopped = true
+	close(m.tickGroupsUpdatedCh)
 	actors := m.actors
 	m.actors = make(map[Actor]actorMgrInfo)
-	m.tickGroupTickers = nil
+	m.names = make(map[string]Actor)
 	m.tickGroups = nil
+	m.schedule = nil
+	if m.significance != nil {
+		m.significance.stop()
+		m.significance = nil
+	}
+
+	log := m.loggerLocked()
+	m.mu.Unlock()
+
+	// the actors are stopped outside of the lock, so that their callbacks (and any observers) may still query the manager
+	log.Debug("manager stopping", slog.Int("actors", len(actors)))
 
-	for _, a := range actors {
-		m.stopActor(a, ErrManagerStopped)
+	for a, ami := range actors {
+		m.discardMailbox(a, ami, ErrManagerStopped)
+		if err := m.stopActor(a, ami, ErrManagerStopped); err != nil {
+			log.Error("actor failed to end play", append(actorLogAttrs(a, ami), slog.Any("error", err))...)
+		}
 	}
+
+	log.Debug("manager stopped")
 }
 
-func (m *Manager) stopActor(a Actor, reason error) error {
-	if err := EndPlay(a, reason); err != nil {
+// stopActor ends play for the actor, then destroys it (or returns it to its pool)
+// the actor is destroyed even if EndPlay() fails, in which case that error is returned
+func (m *Manager) stopActor(a Actor, ami actorMgrInfo, reason error) error {
+	if err := ami.lifecycle.transition("EndPlay", StateEndingPlay, StatePlaying, StateLoading); err != nil {
 		return err
 	}
+	if ami.loading != nil {
+		// no-op if it has already finished loading
+		ami.loading.complete(ErrActorLoadAborted)
+	}
 
-	return nil
+	m.mu.RLock()
+	timeouts := m.lifecycleTimeouts
+	m.mu.RUnlock()
+
+	// ending play isn't cancelled by the manager stopping, so that actors may clean up
+	ctx := withActorName(context.WithoutCancel(m.context()), ami.settings.name)
+	err := endPlay(ctx, a, reason, timeouts.EndPlay)
+	if m.observingLifecycle() {
+		notifyLifecycle(LifecycleEvent{
+			Kind:    EventEndPlay,
+			Actor:   a,
+			Manager: m,
+			Err:     err,
+		})
+	}
+
+	ami.lifecycle.state.Store(int32(StatePendingKill))
+	recycled, derr := destroyActor(ctx, a, ami.lifecycle, timeouts.Destroy)
+	if m.observingLifecycle() {
+		kind := EventDestroyed
+		if recycled {
+			kind = EventRecycled
+		}
+		notifyLifecycle(LifecycleEvent{
+			Kind:    kind,
+			Actor:   a,
+			Manager: m,
+			Err:     derr,
+		})
+	}
+
+	if err == nil {
+		err = derr
+	}
+	return err
 }
 
-// RemoveActor removes the actor from any tick groups and from the managed list of actors
-func (m *Manager) RemoveActor(a Actor, reason error) error {
-	if err := m.removeActorFromLists(a); err != nil {
-		return err
+// context returns the manager's context, which is cancelled when the manager stops ticking
+func (m *Manager) context() context.Context {
+	if m.ctx == nil {
+		return context.Background()
 	}
+	return m.ctx
+}
 
-	m.stopActor(a, reason)
+// RemoveActor removes the actor from any tick groups and from the managed list of actors
+func (m *Manager) RemoveActor(a Actor, reason error) error {
+	return m.removeActors([]Actor{a}, reason)[0]
+}
 
-	return nil
+// RemoveActors removes a batch of actors, taking the manager's lock once and ending play for each in order
+// if any of them fail, a *BatchError holding each actor's error is returned
+func (m *Manager) RemoveActors(actors []Actor, reason error) error {
+	return newBatchError(m.removeActors(actors, reason))
 }
 
-func (m *Manager) removeActorFromLists(a Actor) error {
+func (m *Manager) removeActors(actors []Actor, reason error) []error {
+	errs := make([]error, len(actors))
+	amis := make([]actorMgrInfo, len(actors))
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	for i, a := range actors {
+		amis[i], errs[i] = m.removeActorFromListsLocked(a)
+	}
+	log := m.loggerLocked()
+	m.mu.Unlock()
 
+	for i, a := range actors {
+		if errs[i] != nil {
+			continue
+		}
+
+		log.Debug("actor removed", append(actorLogAttrs(a, amis[i]), slog.Any("reason", reason))...)
+		m.discardMailbox(a, amis[i], ErrActorNotFound)
+		errs[i] = m.stopActor(a, amis[i], reason)
+	}
+
+	return errs
+}
+
+// removeActorFromListsLocked removes the actor from the manager's lists
+// m.mu must be held
+func (m *Manager) removeActorFromListsLocked(a Actor) (actorMgrInfo, error) {
 	ami, found := m.actors[a]
 	if !found {
-		return ErrActorNotFound
+		return ami, ErrActorNotFound
+	}
+
+	for _, hook := range m.removedHooks {
+		hook(a, ami.id)
 	}
 
 	delete(m.actors, a)
+	if ami.settings.name != "" {
+		delete(m.names, ami.settings.name)
+	}
 
-	ticker := ami.tickGroup
+	m.leaveTickGroupLocked(a, ami.settings.tickGroupKey())
 
-	tg, ok := m.tickGroups[ticker]
+	return ami, nil
+}
+
+// leaveTickGroupLocked removes the actor from its tick group, dropping the group once it's empty
+// m.mu must be held
+func (m *Manager) leaveTickGroupLocked(a Actor, key tickGroupKey) {
+	tg, ok := m.tickGroups[key]
 	if !ok {
 		// not in a tick group
-		return nil
+		return
 	}
 
 	delete(tg.list, a)
 
 	if len(tg.list) == 0 {
-		tickerIntv := time.Duration(0)
-		for k, v := range m.tickGroupTickers {
-			if v == ticker {
-				tickerIntv = k
-				break
-			}
+		delete(m.tickGroups, key)
+		if key.scheduled() {
+			m.unscheduleLocked(tg)
 		}
+		m.signalTickGroupsUpdated()
+	}
+}
 
-		delete(m.tickGroups, ticker)
-		delete(m.tickGroupTickers, tickerIntv)
+// joinTickGroupLocked adds the actor to the tick group of the settings provided, creating the group if needed
+// m.mu must be held
+func (m *Manager) joinTickGroupLocked(a Actor, s actorSettings) {
+	if m.stopped {
+		return
 	}
 
-	return nil
+	key := s.tickGroupKey()
+	tg, ok := m.tickGroups[key]
+	if !ok {
+		now := m.clock.Now()
+		tg = &actorList{
+			list:      make(map[Actor]struct{}),
+			interval:  key.interval,
+			phase:     key.phase,
+			calendar:  s.calendar,
+			lastTick:  now,
+			heapIndex: -1,
+		}
+		m.tickGroups[key] = tg
+
+		// the Every-Frame group is only ticked via TickFrame()
+		if key.scheduled() {
+			m.scheduleLocked(tg, now)
+			m.signalTickGroupsUpdated()
+		}
+	}
+
+	tg.list[a] = struct{}{}
+}
+
+// signalTickGroupsUpdated lets the tick goroutine know the schedule has changed
+func (m *Manager) signalTickGroupsUpdated() {
+	if m.stopped {
+		// the tick goroutine is gone, and the channel closed
+		return
+	}
+
+	select {
+	case m.tickGroupsUpdatedCh <- struct{}{}:
+	default:
+		// already signalled
+	}
 }
 
 // AddActor adds an actor to the various lists internally and sets up the tick interval
 func (m *Manager) AddActor(a Actor, opts ...Option) error {
-	if m.stopping {
-		return ErrManagerStopped
+	return m.addActors([]ActorSpec{{Actor: a, Options: opts}})[0]
+}
+
+// ActorSpec is an actor and the options to add it with, for use with AddActors()
+type ActorSpec struct {
+	Actor   Actor
+	Options []Option
+}
+
+// AddActors adds a batch of actors, calling BeginPlay on each in order and taking the manager's lock once, so that the
+// tick groups are only rebuilt once no matter how many actors there are
+// the actors that fail to be added are skipped - if any do, a *BatchError holding each actor's error is returned
+func (m *Manager) AddActors(specs ...ActorSpec) error {
+	return newBatchError(m.addActors(specs))
+}
+
+func (m *Manager) addActors(specs []ActorSpec) []error {
+	errs := make([]error, len(specs))
+	if m.stopping.Load() {
+		for i := range errs {
+			errs[i] = ErrManagerStopped
+		}
+		return errs
 	}
 
+	settings := make([]actorSettings, len(specs))
+	batch := make(map[Actor]struct{}, len(specs))
+	batchNames := make(map[string]struct{})
+
 	m.mu.RLock()
-	_, found := m.actors[a]
-	m.mu.RUnlock()
-	if found {
-		return ErrActorAlreadyAdded
-	}
+	for i, spec := range specs {
+		if _, found := m.actors[spec.Actor]; found {
+			errs[i] = ErrActorAlreadyAdded
+			continue
+		}
+		if _, found := batch[spec.Actor]; found {
+			errs[i] = ErrActorAlreadyAdded
+			continue
+		}
 
-	s := actorSettings{
-		tickInterval: DefaultTickInterval,
-	}
+		s := actorSettings{
+			tickInterval: DefaultTickInterval,
+			loadTimeout:  DefaultLoadTimeout,
+		}
 
-	for _, opt := range opts {
-		opt(&s)
+		for _, opt := range spec.Options {
+			if err := opt(&s); err != nil {
+				errs[i] = err
+				break
+			}
+		}
+		if errs[i] != nil {
+			continue
+		}
+
+		if err := s.resolvePhase(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if s.name != "" {
+			_, taken := m.names[s.name]
+			if _, batchTaken := batchNames[s.name]; taken || batchTaken {
+				errs[i] = errors.Wrapf(ErrActorNameTaken, "name %q", s.name)
+				continue
+			}
+			batchNames[s.name] = struct{}{}
+		}
+
+		batch[spec.Actor] = struct{}{}
+		settings[i] = s
 	}
+	timeouts := m.lifecycleTimeouts
+	m.mu.RUnlock()
 
-	if err := BeginPlay(a); err != nil {
-		return err
+	lcs := make([]*lifecycle, len(specs))
+	loads := make([]*loadState, len(specs))
+	readyChs := make([]<-chan error, len(specs))
+	for i, spec := range specs {
+		if errs[i] != nil {
+			continue
+		}
+
+		a := spec.Actor
+		s := settings[i]
+
+		// actors that weren't spawned via SpawnActor() start being tracked once they're added
+		l := adoptLifecycle(a)
+
+		if t, ok := a.(AsyncBeginPlayIntf); ok {
+			// held out of its tick group until it has finished loading (see: finishLoading())
+			if err := l.transition("AddActor", StateLoading, StateSpawned); err != nil {
+				errs[i] = err
+				continue
+			}
+
+			loads[i], readyChs[i] = m.startLoading(a, t, s)
+			lcs[i] = l
+			continue
+		}
+
+		if err := l.transition("AddActor", StatePlaying, StateSpawned); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		err := beginPlay(withActorName(m.context(), s.name), a, timeouts.BeginPlay)
+		if m.observingLifecycle() {
+			notifyLifecycle(LifecycleEvent{
+				Kind:    EventBeginPlay,
+				Actor:   a,
+				Manager: m,
+				Err:     err,
+			})
+		}
+		if err != nil {
+			abandonLifecycle(a, l)
+			errs[i] = err
+			continue
+		}
+
+		lcs[i] = l
 	}
 
+	// the tick goroutine can't pick up the schedule changes until the lock is released,
+	// so it only does so once for the whole batch
+	aborted := make([]error, len(specs))
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	if m.stopped || m.stopping.Load() {
+		// the manager stopped while the batch was beginning play
+		m.mu.Unlock()
+		for i := range specs {
+			if errs[i] == nil {
+				aborted[i] = ErrManagerStopped
+			}
+		}
+		m.abortAdding(specs, settings, lcs, loads, errs, aborted, timeouts)
+		return errs
+	}
+
+	log := m.loggerLocked()
+	now := m.clock.Now()
+	for i, spec := range specs {
+		if errs[i] != nil {
+			continue
+		}
+
+		a := spec.Actor
+		s := settings[i]
 
-	updatedGroups := false
+		if s.name != "" {
+			// another AddActor() may have claimed the name while the batch was beginning play
+			if _, taken := m.names[s.name]; taken {
+				aborted[i] = errors.Wrapf(ErrActorNameTaken, "name %q", s.name)
+				continue
+			}
+			m.names[s.name] = a
+		}
 
-	var ticker *time.Ticker
-	if s.tickInterval != 0 {
-		if tgt, ok := m.tickGroupTickers[s.tickInterval]; ok {
-			ticker = tgt
-		} else {
-			ticker = time.NewTicker(s.tickInterval)
-			m.tickGroupTickers[s.tickInterval] = ticker
-			updatedGroups = true
+		if loads[i] == nil {
+			m.joinTickGroupLocked(a, s)
+		}
+		m.nextID++
+		m.actors[a] = actorMgrInfo{
+			id:        m.nextID,
+			settings:  s,
+			mailbox:   newMailbox(s.mailbox),
+			stats:     &tickStats{},
+			tickState: &actorTickState{lastTick: now},
+			lifecycle: lcs[i],
+			loading:   loads[i],
 		}
-	} else {
-		// special case for Every-Frame ticking actors
-		ticker = nil
-	}
 
-	m.actors[a] = actorMgrInfo{
-		tickGroup: ticker,
+		if loads[i] != nil {
+			go m.awaitLoading(a, loads[i], readyChs[i], s.loadTimeout)
+		}
+
+		log.Debug("actor added", append(actorLogAttrs(a, m.actors[a]), slog.Duration("tick_interval", s.tickInterval))...)
 	}
+	m.mu.Unlock()
 
-	tg, ok := m.tickGroups[ticker]
-	if !ok {
-		tg = &actorList{
-			list:     make(map[Actor]struct{}),
-			lastTick: time.Now(),
+	m.abortAdding(specs, settings, lcs, loads, errs, aborted, timeouts)
+	return errs
+}
+
+// abortAdding ends play for the actors in a batch that began play (or started loading) but could not be added
+// after all - either the manager stopped or their name was taken in the meantime - leaving them Spawned,
+// as if AddActor() had failed with the reason given in aborted
+func (m *Manager) abortAdding(specs []ActorSpec, settings []actorSettings, lcs []*lifecycle, loads []*loadState, errs, aborted []error, timeouts LifecycleTimeouts) {
+	for i, spec := range specs {
+		if aborted[i] == nil {
+			continue
 		}
-		m.tickGroups[ticker] = tg
-		updatedGroups = true // just in case
+		errs[i] = aborted[i]
+
+		a := spec.Actor
+		l := lcs[i]
+		if err := l.transition("EndPlay", StateEndingPlay, StatePlaying, StateLoading); err != nil {
+			continue
+		}
+		if loads[i] != nil {
+			loads[i].complete(ErrActorLoadAborted)
+		}
+
+		ctx := withActorName(context.WithoutCancel(m.context()), settings[i].name)
+		err := endPlay(ctx, a, aborted[i], timeouts.EndPlay)
+		if m.observingLifecycle() {
+			notifyLifecycle(LifecycleEvent{
+				Kind:    EventEndPlay,
+				Actor:   a,
+				Manager: m,
+				Err:     err,
+			})
+		}
+		abandonLifecycle(a, l)
 	}
+}
 
-	tg.list[a] = struct{}{}
+// actorID returns the unique ID the manager assigned to the actor when it was added
+func (m *Manager) actorID(a Actor) (uint64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ami, found := m.actors[a]
+	return ami.id, found
+}
 
-	if updatedGroups {
-		m.tickGroupsUpdatedCh <- struct{}{}
+// ActorByName returns the actor added to the manager with the Name option provided
+func (m *Manager) ActorByName(name string) (Actor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	a, found := m.names[name]
+	if !found {
+		return nil, errors.Wrapf(ErrActorNotFound, "name %q", name)
 	}
-	return nil
+	return a, nil
 }
 
 // TickFrame triggers a single (manually-fired) frame tick for actors attached to the Every-Frame (interval == 0) tick interval
+// it returns as soon as the frame is queued - see TickFrameSync() to wait for the frame to finish
 func (m *Manager) TickFrame() error {
-	if m.stopping {
+	return m.tickFrame(context.Background(), &frameRequest{})
+}
+
+// TickFrameSync triggers a single frame tick (see: TickFrame()) and waits for every Every-Frame actor to have ticked
+// if any of the actors fail to tick, a *FrameError holding all of their errors is returned
+func (m *Manager) TickFrameSync(ctx context.Context) error {
+	req := frameRequest{
+		doneCh: make(chan error, 1),
+	}
+
+	if err := m.tickFrame(ctx, &req); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-req.doneCh:
+		return err
+	case <-m.context().Done():
+		return ErrManagerStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Manager) tickFrame(ctx context.Context, req *frameRequest) error {
+	if m.stopping.Load() {
 		return ErrManagerStopped
 	}
 
-	m.tickFrameCh <- struct{}{}
+	// tickFrameCh is left open when stopping, as a caller may be blocked here waiting on the previous frame
+	select {
+	case m.tickFrameCh <- req:
+	case <-m.context().Done():
+		return ErrManagerStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	return nil
 }
 
-type waitList struct {
-	cases []reflect.SelectCase
-	tgs   []*actorList
-}
-
-func (m *Manager) generateWaitList(ctx context.Context) *waitList {
-	mgr.mu.RLock()
-	defer mgr.mu.RUnlock()
-	wl := waitList{
-		cases: make([]reflect.SelectCase, 0),
-		tgs:   make([]*actorList, 0),
-	}
-	wl.cases = append(wl.cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(ctx.Done()),
-	})
-	wl.cases = append(wl.cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(mgr.tickGroupsUpdatedCh),
-	})
-	if actors, ok := mgr.tickGroups[nil]; ok {
-		// special case for ticker==nil, which is the Every-Frame group
-		wl.cases = append(wl.cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(mgr.tickFrameCh),
-		})
-		wl.tgs = append(wl.tgs, actors)
+// Frame returns the number of frames that have finished ticking
+func (m *Manager) Frame() uint64 {
+	return m.frames.Load()
+}
+
+// FrameCompleted returns a channel that receives the frame number (see: Frame()) whenever a frame finishes ticking
+// only the most recent frame number is kept if the channel isn't read from in time
+func (m *Manager) FrameCompleted() <-chan uint64 {
+	return m.frameCompletedCh
+}
+
+// processFrame runs on the manager's tick goroutine
+func (m *Manager) processFrame(ctx context.Context, req *frameRequest) {
+	m.mu.RLock()
+	tg := m.tickGroups[tickGroupKey{}]
+	m.mu.RUnlock()
+
+	var errs []error
+	if tg != nil {
+		errs = m.tickActorList(ctx, tg)
 	}
-	for ticker, actors := range mgr.tickGroups {
-		if ticker == nil {
-			continue
+
+	frame := m.frames.Add(1)
+	var err error
+	if len(errs) > 0 {
+		err = &FrameError{
+			Frame:  frame,
+			Errors: errs,
+		}
+	}
+
+	if req.doneCh != nil {
+		req.doneCh <- err
+	}
+
+	for {
+		select {
+		case m.frameCompletedCh <- frame:
+			return
+		default:
+		}
+		// drop the stale frame number
+		select {
+		case <-m.frameCompletedCh:
+		default:
 		}
-		wl.cases = append(wl.cases, reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(ticker.C),
-		})
-		wl.tgs = append(wl.tgs, actors)
 	}
-	return &wl
 }
 
-func (m *Manager) processTickGroups(ctx context.Context) {
-	wl := m.generateWaitList(ctx)
+// tickActorList ticks every actor in the tick group, returning the errors of those that failed to
+func (m *Manager) tickActorList(ctx context.Context, tg *actorList) []error {
+	traced := tracingActive()
+	if traced {
+		var task *trace.Task
+		ctx, task = trace.NewTask(ctx, "actor.TickGroup")
+		defer task.End()
+		trace.Log(ctx, "interval", tg.interval.String())
+
+		if tracer := loadTracing().tracer; tracer != nil {
+			var span Span
+			ctx, span = tracer.StartSpan(ctx, "TickGroup", nil)
+			defer span.End(nil)
+		}
+	}
 
-	go func() {
-		defer m.Stop()
+	// copy the actor list so we can unlock it for other folks
+	m.mu.RLock()
+	actors := make([]Actor, len(tg.list))
+	infos := make([]actorMgrInfo, len(tg.list))
+	i := 0
+	for a := range tg.list {
+		actors[i] = a
+		infos[i] = m.actors[a]
+		i++
+	}
+	hooks := m.tickGroupHooks
+	log := m.loggerLocked()
+	budget, budgeted := m.tickBudgets[tg.interval]
+	if tg.calendar != nil {
+		// budgets are only kept for tick intervals (and the Every-Frame group)
+		budgeted = false
+	}
+	tickTimeout := m.lifecycleTimeouts.Tick
+	clock, epoch := m.clock, m.epoch
+	m.mu.RUnlock()
 
-	mainTickLoop:
-		for {
-			chosen, _, _ := reflect.Select(wl.cases)
-			if chosen == 0 {
-				// done!
-				break mainTickLoop
-			} else if chosen == 1 {
-				// updated!
-				wl = m.generateWaitList(ctx)
-				continue mainTickLoop
-			}
+	deferring := budgeted && budget.DeferRemaining
+	observing := m.observingLifecycle()
+	if deferring {
+		orderForBudget(actors, infos, tg.budgetCursor)
+	}
 
-			tg := wl.tgs[chosen-2]
-			// copy the actor list so we can unlock it for other folks
-			mgr.mu.RLock()
-			actors := make([]Actor, len(tg.list))
-			i := 0
-			for a := range tg.list {
-				actors[i] = a
-				i++
+	// the tick times (and so the deltaTimes) follow the manager's clock, while the tick durations are always real
+	now := clock.Now()
+	start := time.Now()
+	groupDeltaTime := now.Sub(tg.lastTick)
+	scale := m.TimeDilation()
+	frame := m.frames.Load()
+	if tg.interval == 0 {
+		// the frame being ticked
+		frame++
+	}
+	// the time already spent by the interval's other phases this cycle
+	var spent time.Duration
+	if budgeted {
+		cycle := int64(frame)
+		if tg.interval != 0 {
+			cycle = int64(now.Sub(epoch.Add(tg.phase)) / tg.interval)
+		}
+		spent = budget.begin(cycle)
+	}
+	durations := make([]time.Duration, len(actors))
+	status := make([]tickStatus, len(actors))
+	ticked, deferred := 0, 0
+	var errs []error
+actorTickLoop:
+	for i, a := range actors {
+		if deferring && ticked > 0 && spent+time.Since(start) > budget.Budget {
+			// out of time - the rest go first next time
+			for j := i; j < len(actors); j++ {
+				status[j] = tickStatusDeferred
 			}
-			mgr.mu.RUnlock()
-
-			now := time.Now()
-			deltaTime := now.Sub(tg.lastTick)
-		actorTickLoop:
-			for _, a := range actors {
-				if canTick, err := WantTick(a); err != nil {
-					panic(err)
-				} else if !canTick {
-					continue actorTickLoop
-				}
-				if err := Tick(a, deltaTime); err != nil {
-					panic(err)
-				}
+			deferred = len(actors) - i
+			tg.budgetCursor = infos[i].id
+			break actorTickLoop
+		}
+		if infos[i].lifecycle.load() != StatePlaying {
+			// removed since the list was copied
+			status[i] = tickStatusSkipped
+			continue actorTickLoop
+		}
+		ts := infos[i].tickState
+		if canTick, err := WantTick(a); err != nil || !canTick {
+			if err != nil {
+				log.Error("actor WantTick failed", append(actorLogAttrs(a, infos[i]), slog.Any("error", err))...)
+				errs = append(errs, errors.Wrapf(err, "actor %d WantTick", infos[i].id))
+			}
+			if !infos[i].settings.accumulateSkipped {
+				ts.lastTick = now
 			}
-			tg.lastTick = now
+			status[i] = tickStatusSkipped
+			continue actorTickLoop
+		}
+		realDeltaTime := now.Sub(ts.lastTick)
+		tc := TickContext{
+			Frame:         frame,
+			RealDeltaTime: realDeltaTime,
+			DeltaTime:     dilate(realDeltaTime, scale),
+			Interval:      tg.interval,
+			Phase:         tg.phase,
+		}
+		actx := ctx
+		if traced {
+			actx = withActorName(ctx, infos[i].settings.name)
+		}
+		if observing {
+			notifyLifecycle(LifecycleEvent{
+				Kind:      EventTickStart,
+				Actor:     a,
+				Manager:   m,
+				DeltaTime: tc.DeltaTime,
+			})
+		}
+		actorStart := time.Now()
+		err := tick(actx, a, tc, tickTimeout)
+		durations[i] = time.Since(actorStart)
+		ts.lastTick = now
+		if observing {
+			notifyLifecycle(LifecycleEvent{
+				Kind:      EventTickEnd,
+				Actor:     a,
+				Manager:   m,
+				DeltaTime: tc.DeltaTime,
+				Err:       err,
+			})
+		}
+		if err != nil {
+			log.Error("actor tick failed", append(actorLogAttrs(a, infos[i]), slog.Any("error", err))...)
+			errs = append(errs, errors.Wrapf(err, "actor %d Tick", infos[i].id))
+		}
+		ticked++
+	}
+	tg.lastTick = now
+
+	groupDuration := time.Since(start)
+	if tg.interval != 0 && groupDuration > tg.interval {
+		log.Warn("tick group overran its interval",
+			slog.Duration("interval", tg.interval),
+			slog.Duration("duration", groupDuration),
+			slog.Int("actors", len(actors)))
+	}
+
+	var overrun *TickOverrun
+	if budgeted {
+		spent += groupDuration
+		budget.spent = spent
+	}
+	if budgeted && spent > budget.Budget {
+		overrun = &TickOverrun{
+			Interval: tg.interval,
+			Budget:   budget.Budget,
+			Elapsed:  spent,
+			Ticked:   ticked,
+			Deferred: deferred,
 		}
-		// we're done, signal a stop
-		m.tickStoppedCh <- struct{}{}
-	}()
+		log.Warn("tick group exceeded its budget",
+			slog.Duration("interval", overrun.Interval),
+			slog.Duration("budget", overrun.Budget),
+			slog.Duration("elapsed", overrun.Elapsed),
+			slog.Int("ticked", overrun.Ticked),
+			slog.Int("deferred", overrun.Deferred))
+		if budget.OnOverrun != nil {
+			budget.OnOverrun(*overrun)
+		}
+	}
+
+	m.recordTickStats(tg, groupDeltaTime, groupDuration, overrun, actors, infos, durations, status)
+
+	for _, hook := range hooks {
+		hook(actors)
+	}
+
+	return errs
 }
 
 // StartTicking starts the manager ticking
 func (m *Manager) StartTicking(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
+	m.ctx = ctx
 	m.cancelFunc = cancel
 
 	m.processTickGroups(ctx)
@@ -0,0 +1,238 @@
+package actor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrActorCannotRespond is for when an actor is sent a message via Ask() but does not implement RespondIntf
+	ErrActorCannotRespond = errors.New("actor cannot respond")
+)
+
+// Message is anything that may be sent to an actor via Tell()
+type Message interface{}
+
+type pendingMailbox struct {
+	a  Actor
+	mb *mailbox
+}
+
+// Tell sends a message to an actor in the manager
+// the message is delivered to the actor's Receive() function (or HandleCommand(), for persistent actors)
+// on the manager's tick goroutine
+// if the actor's mailbox is bounded and full, what happens depends on its OverflowPolicy (see: BoundedMailbox())
+// messages that can't be delivered are handed to the manager's dead letter observers (see: AddDeadLetterObserver())
+func (m *Manager) Tell(a Actor, msg Message) error {
+	return m.tell(a, envelope{
+		msg: msg,
+	})
+}
+
+// TellFrom sends a message to an actor in the manager on behalf of the sender provided (see: Tell())
+// the sender is reported along with the message if it can't be delivered
+func (m *Manager) TellFrom(sender Actor, a Actor, msg Message) error {
+	return m.tell(a, envelope{
+		msg:    msg,
+		sender: sender,
+	})
+}
+
+func (m *Manager) tell(a Actor, env envelope) error {
+	return m.send(a, env, true)
+}
+
+// tryTell is tell() for senders that can't wait on a full blocking mailbox - the message is undeliverable instead
+func (m *Manager) tryTell(a Actor, env envelope) error {
+	return m.send(a, env, false)
+}
+
+func (m *Manager) send(a Actor, env envelope, wait bool) error {
+	if m.stopping.Load() {
+		m.undeliverable(a, []envelope{env}, ErrManagerStopped)
+		return ErrManagerStopped
+	}
+
+	m.mu.RLock()
+	ami, found := m.actors[a]
+	m.mu.RUnlock()
+	if !found {
+		m.undeliverable(a, []envelope{env}, ErrActorNotFound)
+		return ErrActorNotFound
+	}
+
+	for {
+		res := ami.mailbox.push(env)
+		if res.hasDropped {
+			m.reportOverflow(a, ami, res.dropped)
+		}
+		if errors.Is(res.err, ErrActorNotFound) {
+			// removed while the message was in flight
+			m.undeliverable(a, []envelope{env}, res.err)
+		}
+		if res.err != nil {
+			return res.err
+		}
+		if res.wait != nil {
+			if !wait {
+				m.undeliverable(a, []envelope{env}, ErrMailboxFull)
+				return ErrMailboxFull
+			}
+			select {
+			case <-res.wait:
+				continue
+			case <-m.context().Done():
+				m.undeliverable(a, []envelope{env}, ErrManagerStopped)
+				return ErrManagerStopped
+			}
+		}
+
+		if !res.wasEmpty {
+			// already waiting to be processed
+			return nil
+		}
+		break
+	}
+
+	if ami.lifecycle.load() == StateLoading {
+		// held until it has finished loading (see: finishLoading())
+		return nil
+	}
+
+	m.queueMailbox(a, ami.mailbox)
+	return nil
+}
+
+// queueMailbox queues the actor's mailbox to be processed on the manager's tick goroutine
+func (m *Manager) queueMailbox(a Actor, mb *mailbox) {
+	m.pendingMu.Lock()
+	m.pendingMailboxes = append(m.pendingMailboxes, pendingMailbox{
+		a:  a,
+		mb: mb,
+	})
+	m.pendingMu.Unlock()
+
+	select {
+	case m.mailboxCh <- struct{}{}:
+	default:
+		// already signalled
+	}
+}
+
+type askRequest struct {
+	msg Message
+	// reply completes the asker's future - it's only the first call that counts
+	reply func(Message, error) bool
+}
+
+// Ask sends a message to an actor in the manager and waits for its reply
+// the message is delivered to the actor's Respond() function on the manager's tick goroutine
+// NOTE: this must not be called from the manager's tick goroutine (e.g.: from within Tick()), as it would never complete
+// - use AskAsync() there instead
+func (m *Manager) Ask(ctx context.Context, a Actor, msg Message) (Message, error) {
+	return m.AskAsync(ctx, a, msg).Await(ctx)
+}
+
+// AskAsync sends a message to an actor in the manager and returns a future for its reply, which fails with the
+// context's error if the context is done first
+// the message is delivered to the actor's Respond() function on the manager's tick goroutine, and the future's
+// callbacks run on it too (see: Future.DeliverOn() for actors asking an actor in another manager)
+func (m *Manager) AskAsync(ctx context.Context, a Actor, msg Message) *Future[Message] {
+	f, complete := NewFuture[Message](m)
+	req := &askRequest{
+		msg:   msg,
+		reply: complete,
+	}
+
+	if err := m.tell(a, envelope{
+		msg:  req,
+		kind: DeadLetterAsk,
+	}); err != nil {
+		complete(nil, err)
+		return f
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		complete(nil, ctx.Err())
+	})
+	f.onDone(func() {
+		stop()
+	})
+	return f
+}
+
+// post queues a function to be run on the manager's tick goroutine
+func (m *Manager) post(fn func()) {
+	m.pendingMu.Lock()
+	m.pendingTasks = append(m.pendingTasks, fn)
+	m.pendingMu.Unlock()
+
+	select {
+	case m.mailboxCh <- struct{}{}:
+	default:
+		// already signalled
+	}
+}
+
+func (m *Manager) processMailboxes() {
+	m.pendingMu.Lock()
+	tasks := m.pendingTasks
+	m.pendingTasks = nil
+	pending := m.pendingMailboxes
+	m.pendingMailboxes = nil
+	m.pendingMu.Unlock()
+
+	for _, fn := range tasks {
+		fn()
+	}
+
+	var requeue []pendingMailbox
+	for _, p := range pending {
+		envs, more := p.mb.drain()
+		if more {
+			// over its throughput - the rest wait for the next time around
+			requeue = append(requeue, p)
+		}
+		for i, env := range envs {
+			m.mu.RLock()
+			ami, found := m.actors[p.a]
+			m.mu.RUnlock()
+			if !found {
+				// removed while the messages were in flight
+				m.undeliverable(p.a, envs[i:], ErrActorNotFound)
+				break
+			}
+
+			if req, ok := env.msg.(*askRequest); ok {
+				req.reply(Respond(p.a, req.msg))
+				continue
+			}
+
+			if err := deliverMessage(p.a, env.msg); err != nil {
+				m.logger().Error("actor failed to handle message", append(actorLogAttrs(p.a, ami), slog.Any("error", err))...)
+			}
+		}
+	}
+
+	for _, p := range requeue {
+		m.queueMailbox(p.a, p.mb)
+	}
+}
+
+func failAsks(envs []envelope, err error) {
+	for _, env := range envs {
+		if req, ok := env.msg.(*askRequest); ok {
+			req.reply(nil, err)
+		}
+	}
+}
+
+func deliverMessage(a Actor, msg Message) error {
+	if p, ok := a.(PersistentActorIntf); ok {
+		return persistCommand(p, msg)
+	}
+
+	return Receive(a, msg)
+}
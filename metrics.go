@@ -0,0 +1,228 @@
+package actor
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// TickStats are statistics about the ticks of an actor or of a tick group
+type TickStats struct {
+	TickCount     uint64
+	SkippedCount  uint64
+	DeferredCount uint64
+	LastDuration  time.Duration
+	AvgDuration   time.Duration
+	MaxDuration   time.Duration
+	TotalDuration time.Duration
+}
+
+// ActorStats are statistics about the ticks of a single actor
+type ActorStats struct {
+	TickStats
+	ID       uint64
+	Type     string
+	Name     string
+	Interval time.Duration
+	Phase    time.Duration
+	// Schedule is the actor's wall-clock schedule, if it has one (see: TickCron(), TickAt())
+	Schedule string
+	State    LifecycleState
+	// MailboxLen is the number of messages waiting in the actor's mailbox
+	MailboxLen int
+	// MailboxDropped is the number of messages the actor's mailbox has dropped because it was full
+	MailboxDropped uint64
+}
+
+// TickGroupStats are statistics about the ticks of a tick group
+// lateness is how much longer than its interval a tick group took to tick again
+type TickGroupStats struct {
+	TickStats
+	Interval     time.Duration
+	Phase        time.Duration
+	Schedule     string
+	Actors       int
+	Overruns     uint64
+	LastLateness time.Duration
+	AvgLateness  time.Duration
+	MaxLateness  time.Duration
+}
+
+// ManagerStats is a snapshot of the statistics of a manager's tick groups and actors
+type ManagerStats struct {
+	TickGroups []TickGroupStats
+	Actors     []ActorStats
+	// DeadLetters is the number of things the manager has failed to deliver (see: AddDeadLetterObserver())
+	DeadLetters uint64
+}
+
+// MetricsSink receives tick measurements from a manager as they happen
+// NOTE: these are called from the manager's tick goroutine, so they should be quick
+type MetricsSink interface {
+	ObserveActorTick(a Actor, duration time.Duration)
+	ObserveActorSkipped(a Actor)
+	ObserveTickGroup(interval time.Duration, duration time.Duration, lateness time.Duration)
+	ObserveTickOverrun(overrun TickOverrun)
+}
+
+type tickStats struct {
+	TickStats
+}
+
+func (s *tickStats) observe(d time.Duration) {
+	s.TickCount++
+	s.LastDuration = d
+	s.TotalDuration += d
+	if d > s.MaxDuration {
+		s.MaxDuration = d
+	}
+}
+
+func (s tickStats) snapshot() TickStats {
+	ts := s.TickStats
+	if ts.TickCount > 0 {
+		ts.AvgDuration = ts.TotalDuration / time.Duration(ts.TickCount)
+	}
+	return ts
+}
+
+type tickGroupStats struct {
+	tickStats
+	overruns      uint64
+	lastLateness  time.Duration
+	maxLateness   time.Duration
+	totalLateness time.Duration
+}
+
+// SetMetricsSink sets the sink that receives tick measurements, or clears it if nil
+func (m *Manager) SetMetricsSink(sink MetricsSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metricsSink = sink
+}
+
+func (m *Manager) recordTickStats(tg *actorList, deltaTime time.Duration, groupDuration time.Duration, overrun *TickOverrun, actors []Actor, infos []actorMgrInfo, durations []time.Duration, status []tickStatus) {
+	lateness := time.Duration(0)
+	if tg.interval != 0 && deltaTime > tg.interval {
+		lateness = deltaTime - tg.interval
+	}
+
+	m.mu.RLock()
+	sink := m.metricsSink
+	m.mu.RUnlock()
+
+	m.statsMu.Lock()
+	for i, ami := range infos {
+		s := ami.stats
+		switch status[i] {
+		case tickStatusSkipped:
+			s.SkippedCount++
+			tg.stats.SkippedCount++
+		case tickStatusDeferred:
+			s.DeferredCount++
+			tg.stats.DeferredCount++
+		default:
+			s.observe(durations[i])
+		}
+	}
+	tg.stats.observe(groupDuration)
+	if overrun != nil {
+		tg.stats.overruns++
+	}
+	tg.stats.lastLateness = lateness
+	tg.stats.totalLateness += lateness
+	if lateness > tg.stats.maxLateness {
+		tg.stats.maxLateness = lateness
+	}
+	m.statsMu.Unlock()
+
+	if sink == nil {
+		return
+	}
+
+	for i, a := range actors {
+		switch status[i] {
+		case tickStatusSkipped:
+			sink.ObserveActorSkipped(a)
+		case tickStatusTicked:
+			sink.ObserveActorTick(a, durations[i])
+		}
+	}
+	sink.ObserveTickGroup(tg.interval, groupDuration, lateness)
+	if overrun != nil {
+		sink.ObserveTickOverrun(*overrun)
+	}
+}
+
+// Stats returns a snapshot of the statistics of the manager's tick groups and actors
+func (m *Manager) Stats() ManagerStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+
+	ms := ManagerStats{
+		TickGroups: make([]TickGroupStats, 0, len(m.tickGroups)),
+		Actors:     make([]ActorStats, 0, len(m.actors)),
+
+		DeadLetters: m.deadLetters.count.Load(),
+	}
+
+	for _, tg := range m.tickGroups {
+		var schedule string
+		if tg.calendar != nil {
+			schedule = tg.calendar.String()
+		}
+		tgs := TickGroupStats{
+			TickStats:    tg.stats.snapshot(),
+			Interval:     tg.interval,
+			Phase:        tg.phase,
+			Schedule:     schedule,
+			Actors:       len(tg.list),
+			Overruns:     tg.stats.overruns,
+			LastLateness: tg.stats.lastLateness,
+			MaxLateness:  tg.stats.maxLateness,
+		}
+		if tg.stats.TickCount > 0 {
+			tgs.AvgLateness = tg.stats.totalLateness / time.Duration(tg.stats.TickCount)
+		}
+		ms.TickGroups = append(ms.TickGroups, tgs)
+	}
+
+	for a, ami := range m.actors {
+		var schedule string
+		if ami.settings.calendar != nil {
+			schedule = ami.settings.calendar.String()
+		}
+		ms.Actors = append(ms.Actors, ActorStats{
+			TickStats: ami.stats.snapshot(),
+			ID:        ami.id,
+			Type:      reflect.TypeOf(a).String(),
+			Name:      ami.settings.name,
+			Interval:  ami.settings.tickInterval,
+			Phase:     ami.settings.tickPhase,
+			Schedule:  schedule,
+			State:     ami.lifecycle.load(),
+
+			MailboxLen:     ami.mailbox.len(),
+			MailboxDropped: ami.mailbox.dropped.Load(),
+		})
+	}
+
+	sort.Slice(ms.TickGroups, func(i, j int) bool {
+		if ms.TickGroups[i].Interval != ms.TickGroups[j].Interval {
+			return ms.TickGroups[i].Interval < ms.TickGroups[j].Interval
+		}
+		if ms.TickGroups[i].Phase != ms.TickGroups[j].Phase {
+			return ms.TickGroups[i].Phase < ms.TickGroups[j].Phase
+		}
+		return ms.TickGroups[i].Schedule < ms.TickGroups[j].Schedule
+	})
+	sort.Slice(ms.Actors, func(i, j int) bool {
+		return ms.Actors[i].ID < ms.Actors[j].ID
+	})
+
+	return ms
+}
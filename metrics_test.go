@@ -0,0 +1,169 @@
+package actor_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type metricsActorTest struct {
+	wantTick bool
+}
+
+func (a *metricsActorTest) WantTick() (bool, error) {
+	a.wantTick = !a.wantTick
+	return a.wantTick, nil
+}
+
+func (a *metricsActorTest) Tick(deltaTime time.Duration) error {
+	return nil
+}
+
+type metricsSinkTest struct {
+	mu      sync.Mutex
+	ticks   int
+	skipped int
+	groups  int
+}
+
+func (s *metricsSinkTest) ObserveActorTick(a actor.Actor, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ticks++
+}
+
+func (s *metricsSinkTest) ObserveActorSkipped(a actor.Actor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipped++
+}
+
+func (s *metricsSinkTest) ObserveTickGroup(interval time.Duration, duration time.Duration, lateness time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups++
+}
+
+func (s *metricsSinkTest) ObserveTickOverrun(overrun actor.TickOverrun) {
+}
+
+func TestManagerStats(t *testing.T) {
+	m := actor.NewManager()
+	sink := &metricsSinkTest{}
+	m.SetMetricsSink(sink)
+	m.StartTicking(context.Background())
+	defer m.Stop()
+
+	if err := m.AddActor(&metricsActorTest{}, actor.TickInterval(time.Millisecond), actor.Name("metrics")); err != nil {
+		t.Fatal(err)
+	}
+
+	var stats actor.ManagerStats
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats = m.Stats()
+		if len(stats.TickGroups) == 1 && stats.TickGroups[0].TickCount >= 10 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tick group did not tick enough - got %+v", stats)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(stats.Actors) != 1 {
+		t.Fatalf("expected 1 actor, got %d", len(stats.Actors))
+	}
+
+	as := stats.Actors[0]
+	if as.Name != "metrics" || as.Interval != time.Millisecond {
+		t.Fatalf("unexpected actor stats %+v", as)
+	}
+
+	if as.TickCount == 0 || as.SkippedCount == 0 {
+		t.Fatalf("expected both ticks and skips, got %+v", as)
+	}
+
+	if as.MaxDuration < as.AvgDuration {
+		t.Fatalf("max duration %v less than avg duration %v", as.MaxDuration, as.AvgDuration)
+	}
+
+	tgs := stats.TickGroups[0]
+	if tgs.Actors != 1 || tgs.Interval != time.Millisecond {
+		t.Fatalf("unexpected tick group stats %+v", tgs)
+	}
+
+	sink.mu.Lock()
+	if sink.ticks == 0 || sink.skipped == 0 || sink.groups == 0 {
+		t.Fatalf("metrics sink not notified - got %+v", sink)
+	}
+	sink.mu.Unlock()
+
+	rec := httptest.NewRecorder()
+	actor.PrometheusHandler(m, true).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+
+	for _, expected := range []string{
+		`# TYPE actor_tick_group_ticks_total counter`,
+		`actor_tick_group_actors{interval="1ms",phase="0s",schedule=""} 1`,
+		`actor_tick_group_lateness_seconds{interval="1ms",phase="0s",schedule="",stat="max"}`,
+		`actor_ticks_total{id="1",type="*actor_test.metricsActorTest",name="metrics"}`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("expected %q in exported metrics:\n%s", expected, body)
+		}
+	}
+}
+
+func TestPrometheusLabelEscaping(t *testing.T) {
+	m := startTestManager(t)
+
+	// only backslashes, quotes and newlines are escaped - unlike Go's quoting, tabs and unicode are left alone
+	if err := m.AddActor(&metricsActorTest{}, actor.TickInterval(time.Hour), actor.Name("tab\there \"q\" back\\slash\nnew é")); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	actor.PrometheusHandler(m, true).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+
+	expected := `name="tab` + "\t" + `here \"q\" back\\slash\nnew é"`
+	if !strings.Contains(string(body), expected) {
+		t.Fatalf("expected %s in exported metrics:\n%s", expected, body)
+	}
+}
+
+func TestPrometheusTickGroupLabels(t *testing.T) {
+	m := startTestManager(t)
+
+	for _, opts := range [][]actor.Option{
+		{actor.TickInterval(time.Hour)},
+		{actor.TickInterval(time.Hour), actor.TickPhase(time.Minute)},
+		{actor.TickCron("0 0 * * * *")},
+	} {
+		if err := m.AddActor(&metricsActorTest{}, opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	actor.PrometheusHandler(m, false).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+
+	// every group has the same set of labels, whichever of them apply to it
+	for _, expected := range []string{
+		`actor_tick_group_actors{interval="1h0m0s",phase="0s",schedule=""} 1`,
+		`actor_tick_group_actors{interval="1h0m0s",phase="1m0s",schedule=""} 1`,
+		`actor_tick_group_actors{interval="0s",phase="0s",schedule="0 0 * * * *"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Fatalf("expected %q in exported metrics:\n%s", expected, body)
+		}
+	}
+}
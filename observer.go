@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"fmt"
+	"time"
+)
+
+// LifecycleEventKind is the kind of lifecycle transition a LifecycleEvent describes
+type LifecycleEventKind int
+
+const (
+	// EventSpawned is sent once an actor has finished spawning (see: FinishSpawningActor())
+	EventSpawned = LifecycleEventKind(iota)
+	// EventBeginPlay is sent after an actor added to a manager has had BeginPlay() called
+	EventBeginPlay
+	// EventTickStart is sent right before an actor is ticked by a manager
+	EventTickStart
+	// EventTickEnd is sent right after an actor is ticked by a manager
+	EventTickEnd
+	// EventEndPlay is sent after an actor removed from a manager has had EndPlay() called
+	EventEndPlay
+	// EventDestroyed is sent after an actor has had BeginDestroy() and FinishDestroy() called
+	EventDestroyed
+	// EventRecycled is sent instead of EventDestroyed when a pooled actor is returned to its pool (see: ActorPool)
+	EventRecycled
+)
+
+func (k LifecycleEventKind) String() string {
+	switch k {
+	case EventSpawned:
+		return "Spawned"
+	case EventBeginPlay:
+		return "BeginPlay"
+	case EventTickStart:
+		return "TickStart"
+	case EventTickEnd:
+		return "TickEnd"
+	case EventEndPlay:
+		return "EndPlay"
+	case EventDestroyed:
+		return "Destroyed"
+	case EventRecycled:
+		return "Recycled"
+	default:
+		return fmt.Sprintf("LifecycleEventKind(%d)", int(k))
+	}
+}
+
+// LifecycleEvent describes a single lifecycle transition of an actor
+type LifecycleEvent struct {
+	Kind  LifecycleEventKind
+	Actor Actor
+	// Manager is the manager the actor belongs to - nil for EventSpawned, as actors are spawned outside of managers
+	Manager *Manager
+	// DeltaTime is the time passed to Tick(), for EventTickStart and EventTickEnd
+	DeltaTime time.Duration
+	// Err is the error returned by the actor's callback(s), if any
+	Err error
+}
+
+// LifecycleObserver is notified of actor lifecycle transitions
+// observers are called synchronously from whichever goroutine made the transition (the manager's tick goroutine for ticks),
+// so they must be quick and must not call back into the manager in ways that would wait on that goroutine
+type LifecycleObserver interface {
+	ObserveLifecycle(ev LifecycleEvent)
+}
+
+// LifecycleObserverFunc lets an ordinary function be used as a LifecycleObserver
+type LifecycleObserverFunc func(ev LifecycleEvent)
+
+// ObserveLifecycle calls f(ev)
+func (f LifecycleObserverFunc) ObserveLifecycle(ev LifecycleEvent) {
+	f(ev)
+}
+
+// observerList is the list of lifecycle observers, which are notified without taking a lock
+type observerList struct {
+	cowList[LifecycleObserver]
+}
+
+func (l *observerList) notify(ev LifecycleEvent) {
+	l.each(func(o LifecycleObserver) {
+		o.ObserveLifecycle(ev)
+	})
+}
+
+var globalObservers observerList
+
+// AddLifecycleObserver registers an observer that is notified of the lifecycle transitions of every actor
+// the function returned unregisters the observer
+func AddLifecycleObserver(o LifecycleObserver) func() {
+	return globalObservers.add(o)
+}
+
+// AddLifecycleObserver registers an observer that is notified of the lifecycle transitions of the manager's actors
+// the function returned unregisters the observer
+func (m *Manager) AddLifecycleObserver(o LifecycleObserver) func() {
+	return m.observers.add(o)
+}
+
+// observingLifecycle reports if there are any observers for the manager's actors, so callers can skip building events
+func (m *Manager) observingLifecycle() bool {
+	return globalObservers.active() || (m != nil && m.observers.active())
+}
+
+// notifyLifecycle sends the event to the global observers, then to the event's manager's observers
+func notifyLifecycle(ev LifecycleEvent) {
+	globalObservers.notify(ev)
+	if ev.Manager != nil {
+		ev.Manager.observers.notify(ev)
+	}
+}
@@ -0,0 +1,107 @@
+package actor_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type observerActorTest struct {
+	ticks int
+}
+
+func (a *observerActorTest) Tick(deltaTime time.Duration) error {
+	a.ticks++
+	return nil
+}
+
+type lifecycleRecorderTest struct {
+	mu     sync.Mutex
+	events []actor.LifecycleEvent
+}
+
+func (r *lifecycleRecorderTest) ObserveLifecycle(ev actor.LifecycleEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ev)
+}
+
+// kinds returns the kinds of the events observed for the actor provided
+func (r *lifecycleRecorderTest) kinds(a actor.Actor) []actor.LifecycleEventKind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var kinds []actor.LifecycleEventKind
+	for _, ev := range r.events {
+		if ev.Actor == a {
+			kinds = append(kinds, ev.Kind)
+		}
+	}
+	return kinds
+}
+
+// collapseTicks squashes repeated tick start/end pairs into one, so the sequence doesn't depend on how many ticks happened
+func collapseTicks(kinds []actor.LifecycleEventKind) []actor.LifecycleEventKind {
+	var out []actor.LifecycleEventKind
+	for i := 0; i < len(kinds); i++ {
+		if kinds[i] == actor.EventTickStart && len(out) >= 2 && out[len(out)-1] == actor.EventTickEnd {
+			i++ // skip the matching end
+			continue
+		}
+		out = append(out, kinds[i])
+	}
+	return out
+}
+
+func TestLifecycleObservers(t *testing.T) {
+	m := startTestManager(t)
+
+	global := &lifecycleRecorderTest{}
+	local := &lifecycleRecorderTest{}
+
+	removeGlobal := actor.AddLifecycleObserver(global)
+	defer removeGlobal()
+	removeLocal := m.AddLifecycleObserver(local)
+	defer removeLocal()
+
+	act, err := actor.SpawnActor(reflect.TypeOf(observerActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddActor(act, actor.TickInterval(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(local.kinds(act)) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("ticks not observed - got %v", local.kinds(act))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := m.RemoveActor(act, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []actor.LifecycleEventKind{
+		actor.EventSpawned,
+		actor.EventBeginPlay,
+		actor.EventTickStart,
+		actor.EventTickEnd,
+		actor.EventEndPlay,
+		actor.EventDestroyed,
+	}
+	if got := collapseTicks(global.kinds(act)); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected global events %v, got %v", expected, got)
+	}
+	// managers don't see spawns, as actors are spawned outside of them
+	if got := collapseTicks(local.kinds(act)); !reflect.DeepEqual(got, expected[1:]) {
+		t.Fatalf("expected manager events %v, got %v", expected[1:], got)
+	}
+}
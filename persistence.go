@@ -0,0 +1,173 @@
+package actor
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotPersistentActor is for when persistence options are used on an actor that does not implement PersistentActorIntf
+	ErrNotPersistentActor = errors.New("actor is not a persistent actor")
+)
+
+// Event is a fact produced by a persistent actor while handling a command
+// events are appended to the actor's Journal and replayed to rebuild its state
+type Event interface{}
+
+// PersistentActor is embedded into actors that want to be event-sourced (see: PersistentActorIntf)
+type PersistentActor struct {
+	journal       Journal
+	snapshotEvery uint64
+	sequenceNr    uint64
+	sinceSnapshot uint64
+}
+
+func (p *PersistentActor) persistence() *PersistentActor {
+	return p
+}
+
+// LastSequenceNr returns the sequence number of the last event persisted or replayed
+func (p *PersistentActor) LastSequenceNr() uint64 {
+	return p.sequenceNr
+}
+
+// PersistentActorIntf is for actors that want to have their state event-sourced
+// messages sent via Tell() are passed to HandleCommand(), and the events it returns are appended to the journal
+// and then passed to ApplyEvent(). Actors implementing this must embed PersistentActor
+type PersistentActorIntf interface {
+	PersistenceID() string
+	HandleCommand(cmd Message) ([]Event, error)
+	ApplyEvent(evt Event) error
+	persistence() *PersistentActor
+}
+
+// PersistenceJournal sets the journal that a persistent actor's events are appended to and recovered from
+// recovery happens during FinishSpawningActor, right before OnConstruction() is called
+func PersistenceJournal(j Journal) SpawnActorOption {
+	return func(s *spawnActorSettings) error {
+		s.journal = j
+		return nil
+	}
+}
+
+// SnapshotEvery sets a persistent actor to save a snapshot of its state after every n events,
+// which bounds the number of events that need to be replayed during recovery
+func SnapshotEvery(n uint64) SpawnActorOption {
+	return func(s *spawnActorSettings) error {
+		s.snapshotEvery = n
+		return nil
+	}
+}
+
+func recoverPersistentActor(a Actor, s *spawnActorSettings) error {
+	if s.journal == nil {
+		return nil
+	}
+
+	pa, ok := a.(PersistentActorIntf)
+	if !ok {
+		return errors.Wrapf(ErrNotPersistentActor, "unexpected type %v", reflect.TypeOf(a))
+	}
+
+	p := pa.persistence()
+	p.journal = s.journal
+	p.snapshotEvery = s.snapshotEvery
+
+	id := pa.PersistenceID()
+
+	seq, data, err := p.journal.LoadSnapshot(id)
+	if err == nil {
+		if err := unmarshalActorState(a, data); err != nil {
+			return err
+		}
+		p.sequenceNr = seq
+	} else if !errors.Is(err, ErrSnapshotNotFound) {
+		return err
+	}
+
+	return p.journal.Replay(id, p.sequenceNr+1, func(entry JournalEntry) error {
+		evt, err := decodeEvent(entry)
+		if err != nil {
+			return err
+		}
+
+		if err := pa.ApplyEvent(evt); err != nil {
+			return err
+		}
+
+		p.sequenceNr = entry.SequenceNr
+		p.sinceSnapshot++
+		return nil
+	})
+}
+
+func persistCommand(pa PersistentActorIntf, cmd Message) error {
+	events, err := pa.HandleCommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	p := pa.persistence()
+	id := pa.PersistenceID()
+
+	if p.journal != nil {
+		entries := make([]JournalEntry, len(events))
+		for i, evt := range events {
+			if entries[i], err = encodeEvent(p.sequenceNr+uint64(i)+1, evt); err != nil {
+				return err
+			}
+		}
+
+		if err := p.journal.Append(id, entries); err != nil {
+			return err
+		}
+	}
+
+	// the events are in the journal now, so their sequence numbers are used up even if applying one fails
+	p.sequenceNr += uint64(len(events))
+	p.sinceSnapshot += uint64(len(events))
+
+	for _, evt := range events {
+		if err := pa.ApplyEvent(evt); err != nil {
+			return err
+		}
+	}
+
+	if p.journal != nil && p.snapshotEvery > 0 && p.sinceSnapshot >= p.snapshotEvery {
+		data, err := marshalActorState(pa)
+		if err != nil {
+			return err
+		}
+
+		if err := p.journal.SaveSnapshot(id, p.sequenceNr, data); err != nil {
+			return err
+		}
+		p.sinceSnapshot = 0
+	}
+
+	return nil
+}
+
+func encodeEvent(seq uint64, evt Event) (JournalEntry, error) {
+	class, data, err := marshalClass(evt)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	return JournalEntry{
+		SequenceNr: seq,
+		Class:      class,
+		Data:       data,
+	}, nil
+}
+
+// decodeEvent re-creates an event from a journal entry
+// NOTE: events are always replayed as values of their registered class, never as pointers
+func decodeEvent(entry JournalEntry) (Event, error) {
+	return unmarshalClass(entry.Class, entry.Data)
+}
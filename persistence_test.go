@@ -0,0 +1,180 @@
+package actor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type addCommand struct {
+	Amount int
+}
+
+type addedEvent struct {
+	Amount int
+}
+
+type persistentActorTest struct {
+	actor.PersistentActor
+
+	ID    string
+	Total int
+
+	applied int
+	handled chan struct{}
+}
+
+func (a *persistentActorTest) PersistenceID() string {
+	return a.ID
+}
+
+func (a *persistentActorTest) HandleCommand(cmd actor.Message) ([]actor.Event, error) {
+	if c, ok := cmd.(addCommand); ok {
+		return []actor.Event{addedEvent{Amount: c.Amount}}, nil
+	}
+	return nil, nil
+}
+
+func (a *persistentActorTest) ApplyEvent(evt actor.Event) error {
+	defer func() {
+		a.handled <- struct{}{}
+	}()
+	if e, ok := evt.(addedEvent); ok {
+		if e.Amount < 0 {
+			return errors.New("negative amount")
+		}
+		a.Total += e.Amount
+		a.applied++
+	}
+	return nil
+}
+
+func init() {
+	if err := actor.RegisterClass("addedEvent", reflect.TypeOf(addedEvent{})); err != nil {
+		panic(err)
+	}
+}
+
+func spawnPersistentActorTest(t *testing.T, j actor.Journal, opts ...actor.SpawnActorOption) *persistentActorTest {
+	t.Helper()
+
+	opts = append(opts, actor.DeferredSpawnActor(), actor.PersistenceJournal(j))
+	act, err := actor.SpawnActor(reflect.TypeOf(persistentActorTest{}), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := act.(*persistentActorTest)
+	a.ID = "counter-1"
+	a.handled = make(chan struct{}, 16)
+
+	if err := actor.FinishSpawningActor(a, opts...); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func testPersistentActorRecovery(t *testing.T, j actor.Journal) {
+	m := startTestManager(t)
+
+	a := spawnPersistentActorTest(t, j, actor.SnapshotEvery(3))
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		if err := m.Tell(a, addCommand{Amount: i}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-a.handled:
+		case <-time.After(time.Second):
+			t.Fatal("command not handled")
+		}
+	}
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Total != 10 {
+		t.Fatalf("expected total of 10, got %d", a.Total)
+	}
+
+	r := spawnPersistentActorTest(t, j, actor.SnapshotEvery(3))
+	if r.Total != 10 {
+		t.Fatalf("state not recovered - expected total of 10, got %d", r.Total)
+	}
+
+	if r.LastSequenceNr() != 4 {
+		t.Fatalf("expected sequence number 4, got %d", r.LastSequenceNr())
+	}
+
+	// a snapshot was taken after the 3rd event, so only the 4th should be replayed
+	if r.applied != 1 {
+		t.Fatalf("expected 1 event replayed after the snapshot, got %d", r.applied)
+	}
+}
+
+func TestPersistentActorMemoryJournal(t *testing.T) {
+	testPersistentActorRecovery(t, actor.NewMemoryJournal())
+}
+
+func TestPersistentActorFileJournal(t *testing.T) {
+	j, err := actor.NewFileJournal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testPersistentActorRecovery(t, j)
+}
+
+func TestPersistenceJournalRequiresPersistentActor(t *testing.T) {
+	if _, err := actor.SpawnActor(reflect.TypeOf(spawnActorTest{}), actor.PersistenceJournal(actor.NewMemoryJournal())); err == nil {
+		t.Fatal("expected an error spawning a non-persistent actor with a journal")
+	}
+}
+
+func TestPersistentActorApplyEventFailure(t *testing.T) {
+	m := startTestManager(t)
+
+	j := actor.NewMemoryJournal()
+	a := spawnPersistentActorTest(t, j)
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first event fails to apply, but it's already in the journal
+	for _, amount := range []int{-1, 5} {
+		if err := m.Tell(a, addCommand{Amount: amount}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-a.handled:
+		case <-time.After(time.Second):
+			t.Fatal("command not handled")
+		}
+	}
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.LastSequenceNr() != 2 {
+		t.Fatalf("expected sequence number 2, got %d", a.LastSequenceNr())
+	}
+
+	var seqs []uint64
+	if err := j.Replay(a.ID, 1, func(entry actor.JournalEntry) error {
+		seqs = append(seqs, entry.SequenceNr)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Fatalf("expected sequence numbers [1 2] in the journal, got %v", seqs)
+	}
+}
@@ -0,0 +1,117 @@
+package actor_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type phaseActorTest struct {
+	phase time.Duration
+	rec   *phaseRecorderTest
+}
+
+func (a *phaseActorTest) Tick(deltaTime time.Duration) error {
+	a.rec.record(a.phase, deltaTime)
+	return nil
+}
+
+type phaseRecorderTest struct {
+	mu     sync.Mutex
+	ticks  map[time.Duration][]time.Time
+	deltas []time.Duration
+}
+
+func (r *phaseRecorderTest) record(phase time.Duration, deltaTime time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ticks[phase] = append(r.ticks[phase], time.Now())
+	r.deltas = append(r.deltas, deltaTime)
+}
+
+func TestTickPhase(t *testing.T) {
+	const interval = 200 * time.Millisecond
+	phases := []time.Duration{0, 50 * time.Millisecond, 100 * time.Millisecond, 150 * time.Millisecond}
+
+	m := startTestManager(t)
+
+	if err := m.AddActor(&phaseActorTest{}, actor.TickInterval(interval), actor.TickPhase(-time.Millisecond)); !errors.Is(err, actor.ErrInvalidTickPhase) {
+		t.Fatalf("expected ErrInvalidTickPhase, got %v", err)
+	}
+
+	rec := &phaseRecorderTest{
+		ticks: make(map[time.Duration][]time.Time),
+	}
+	for i := 0; i < 20; i++ {
+		phase := phases[i%len(phases)]
+		if err := m.AddActor(&phaseActorTest{phase: phase, rec: rec}, actor.TickInterval(interval), actor.TickPhase(phase)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := m.Stats()
+	if len(stats.TickGroups) != len(phases) {
+		t.Fatalf("expected %d tick groups, got %+v", len(phases), stats.TickGroups)
+	}
+	for i, tgs := range stats.TickGroups {
+		if tgs.Interval != interval || tgs.Phase != phases[i] || tgs.Actors != 5 {
+			t.Fatalf("unexpected tick group stats %+v", tgs)
+		}
+	}
+
+	time.Sleep(3*interval + interval/2)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	// each phase ticks in its own batch, offset from the one before it
+	first := make([]time.Time, len(phases))
+	for i, phase := range phases {
+		if len(rec.ticks[phase]) < 5 {
+			t.Fatalf("expected phase %v to have ticked, got %d ticks", phase, len(rec.ticks[phase]))
+		}
+		first[i] = rec.ticks[phase][0]
+	}
+	for i := 1; i < len(first); i++ {
+		gap := first[i].Sub(first[i-1])
+		if gap < 0 {
+			gap += interval
+		}
+		if gap < 25*time.Millisecond || gap > 75*time.Millisecond {
+			t.Fatalf("expected phases to tick ~50ms apart, got %v between %v and %v", gap, phases[i-1], phases[i])
+		}
+	}
+
+	for _, dt := range rec.deltas[len(phases)*5:] {
+		if dt < interval/2 || dt > 2*interval {
+			t.Fatalf("expected deltaTime close to %v, got %v", interval, dt)
+		}
+	}
+}
+
+func TestTickJitter(t *testing.T) {
+	const interval = time.Hour
+
+	m := actor.NewManager()
+	defer m.Stop()
+
+	for i := 0; i < 100; i++ {
+		if err := m.AddActor(&phaseActorTest{}, actor.TickInterval(interval), actor.TickJitter(interval, 15*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := m.Stats()
+	if len(stats.TickGroups) < 2 || len(stats.TickGroups) > 4 {
+		t.Fatalf("expected actors to be spread across 2-4 batches, got %d", len(stats.TickGroups))
+	}
+	for _, tgs := range stats.TickGroups {
+		if tgs.Phase%(15*time.Minute) != 0 {
+			t.Fatalf("expected phases to be multiples of the jitter step, got %v", tgs.Phase)
+		}
+	}
+}
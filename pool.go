@@ -0,0 +1,181 @@
+package actor
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidPoolSize is for when a negative maximum size is provided to an ActorPool
+var ErrInvalidPoolSize = errors.New("invalid pool size")
+
+type actorPoolSettings struct {
+	maxSize int
+}
+
+// ActorPoolOption is a function that sets up an option during the NewActorPool function
+type ActorPoolOption func(*actorPoolSettings) error
+
+// MaxPoolSize limits the number of idle actors a pool keeps around - actors released once it's full are destroyed
+// zero (the default) doesn't limit it
+func MaxPoolSize(n int) ActorPoolOption {
+	return func(s *actorPoolSettings) error {
+		if n < 0 {
+			return errors.Wrapf(ErrInvalidPoolSize, "%d", n)
+		}
+
+		s.maxSize = n
+		return nil
+	}
+}
+
+// ActorPool recycles actors of a single type, so that short-lived actors don't have to be allocated anew every time
+// actors spawned via PooledSpawn() are returned to their pool when they are removed from their manager, having
+// Reset() called on them instead of FinishDestroy()
+type ActorPool struct {
+	typ      reflect.Type
+	settings actorPoolSettings
+
+	mu   sync.Mutex
+	idle []Actor
+}
+
+// NewActorPool creates a new pool of actors of the type provided (the same type that would be passed to SpawnActor())
+func NewActorPool(typ reflect.Type, opts ...ActorPoolOption) (*ActorPool, error) {
+	s := actorPoolSettings{}
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	p := ActorPool{
+		typ:      typ,
+		settings: s,
+	}
+
+	return &p, nil
+}
+
+// Prewarm allocates idle actors until there are n of them (or the pool is full)
+// PostSpawnInitialize() is called on each actor as it is allocated, and is not called again when it is reused
+func (p *ActorPool) Prewarm(n int) error {
+	for {
+		p.mu.Lock()
+		idle := len(p.idle)
+		full := p.settings.maxSize > 0 && idle >= p.settings.maxSize
+		p.mu.Unlock()
+		if idle >= n || full {
+			return nil
+		}
+
+		a, err := allocateActor(p.typ, p, spawnActorSettings{})
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		p.idle = append(p.idle, a)
+		p.mu.Unlock()
+	}
+}
+
+// Len returns the number of idle actors in the pool
+func (p *ActorPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.idle)
+}
+
+// get takes an idle actor from the pool, or allocates a new one if there are none
+func (p *ActorPool) get(s spawnActorSettings) (Actor, error) {
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		a := p.idle[n-1]
+		p.idle[n-1] = nil
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		return a, nil
+	}
+	p.mu.Unlock()
+
+	return allocateActor(p.typ, p, s)
+}
+
+// take removes an idle actor from the pool, reporting whether it was there
+func (p *ActorPool) take(a Actor) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, ia := range p.idle {
+		if ia != a {
+			continue
+		}
+
+		last := len(p.idle) - 1
+		copy(p.idle[i:], p.idle[i+1:])
+		p.idle[last] = nil
+		p.idle = p.idle[:last]
+		return true
+	}
+	return false
+}
+
+// release resets an actor that is being destroyed and returns it to the pool, if there is room for it
+func (p *ActorPool) release(ctx context.Context, a Actor, l *lifecycle, timeout time.Duration) (bool, error) {
+	p.mu.Lock()
+	full := p.settings.maxSize > 0 && len(p.idle) >= p.settings.maxSize
+	p.mu.Unlock()
+	if full {
+		return false, nil
+	}
+
+	if err := reset(ctx, a, timeout); err != nil {
+		return false, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.settings.maxSize > 0 && len(p.idle) >= p.settings.maxSize {
+		// filled up while resetting
+		return false, nil
+	}
+
+	l.state.Store(int32(StatePostSpawnInitialized))
+	p.idle = append(p.idle, a)
+	return true, nil
+}
+
+// PooledSpawn spawns an actor from the pool provided, much like SpawnActor() does
+// the construction stages (ExecuteConstruction() through OnActorSpawned()) are run every time the actor is reused
+func PooledSpawn(p *ActorPool, opts ...SpawnActorOption) (Actor, error) {
+	s := spawnActorSettings{}
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+
+	a, err := p.get(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.deferredSpawn {
+		s.log().Debug("actor spawn deferred", slog.String("actor_type", reflect.TypeOf(a).String()))
+		return a, nil
+	}
+
+	if err := FinishSpawningActor(a, opts...); err != nil {
+		// it's no longer tracked, so it won't be returned to the pool
+		return nil, err
+	}
+
+	return a, nil
+}
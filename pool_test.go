@@ -0,0 +1,151 @@
+package actor_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type pooledActorTest struct {
+	hitPostSpawnInitialize int
+	hitOnConstruction      int
+	hitReset               int
+	hitFinishDestroy       int
+}
+
+func (a *pooledActorTest) PostSpawnInitialize() error {
+	a.hitPostSpawnInitialize++
+	return nil
+}
+
+func (a *pooledActorTest) OnConstruction() error {
+	a.hitOnConstruction++
+	return nil
+}
+
+func (a *pooledActorTest) Reset() error {
+	a.hitReset++
+	return nil
+}
+
+func (a *pooledActorTest) FinishDestroy() error {
+	a.hitFinishDestroy++
+	return nil
+}
+
+func TestActorPool(t *testing.T) {
+	m := startTestManager(t)
+
+	p, err := actor.NewActorPool(reflect.TypeOf(pooledActorTest{}), actor.MaxPoolSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Prewarm(2); err != nil {
+		t.Fatal(err)
+	}
+	if p.Len() != 1 {
+		t.Fatalf("expected prewarm to stop at the max pool size, got %d", p.Len())
+	}
+
+	act, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := act.(*pooledActorTest)
+	if p.Len() != 0 || a.hitPostSpawnInitialize != 1 || a.hitOnConstruction != 1 {
+		t.Fatalf("expected the prewarmed actor to be constructed, got %+v (%d idle)", a, p.Len())
+	}
+
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.hitReset != 1 || a.hitFinishDestroy != 0 || p.Len() != 1 {
+		t.Fatalf("expected the actor to be reset and returned to the pool, got %+v (%d idle)", a, p.Len())
+	}
+	if state := actor.State(a); state != actor.StatePostSpawnInitialized {
+		t.Fatalf("expected pooled actor to be PostSpawnInitialized, got %v", state)
+	}
+
+	reused, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reused != act || a.hitPostSpawnInitialize != 1 || a.hitOnConstruction != 2 {
+		t.Fatalf("expected the actor to be reused and reconstructed, got %+v", a)
+	}
+
+	extra, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extra == reused {
+		t.Fatal("expected a new actor from an empty pool")
+	}
+
+	for _, pa := range []actor.Actor{reused, extra} {
+		if err := m.AddActor(pa, actor.TickInterval(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.RemoveActor(pa, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the pool only holds one, so the extra actor is destroyed
+	if e := extra.(*pooledActorTest); e.hitFinishDestroy != 1 || e.hitReset != 0 || p.Len() != 1 {
+		t.Fatalf("expected the extra actor to be destroyed, got %+v (%d idle)", e, p.Len())
+	}
+}
+
+func TestDestroyIdlePooledActor(t *testing.T) {
+	m := startTestManager(t)
+
+	p, err := actor.NewActorPool(reflect.TypeOf(pooledActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	act, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddActor(act, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RemoveActor(act, nil); err != nil {
+		t.Fatal(err)
+	}
+	if p.Len() != 1 {
+		t.Fatalf("expected the actor to be returned to the pool, got %d idle", p.Len())
+	}
+
+	if err := actor.DestroyActor(act); err != nil {
+		t.Fatal(err)
+	}
+	a := act.(*pooledActorTest)
+	if a.hitFinishDestroy != 1 || p.Len() != 0 {
+		t.Fatalf("expected the idle actor to be destroyed and taken out of the pool, got %+v (%d idle)", a, p.Len())
+	}
+	if state := actor.State(act); state != actor.StateUnknown {
+		t.Fatalf("expected destroyed actor to no longer be tracked, got %v", state)
+	}
+
+	first, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := actor.PooledSpawn(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == act || second == act || first == second {
+		t.Fatal("expected the destroyed actor not to be handed out again")
+	}
+}
@@ -0,0 +1,177 @@
+package actor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type prometheusHandler struct {
+	m             *Manager
+	includeActors bool
+}
+
+// PrometheusHandler returns an http.Handler that serves the manager's Stats() in the Prometheus text exposition format
+// per-actor series are only included if includeActors is set, as they may be numerous
+func PrometheusHandler(m *Manager, includeActors bool) http.Handler {
+	h := prometheusHandler{
+		m:             m,
+		includeActors: includeActors,
+	}
+
+	return &h
+}
+
+func (h *prometheusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	writePrometheusStats(bw, h.m.Stats(), h.includeActors)
+}
+
+type prometheusMetric struct {
+	name string
+	help string
+	typ  string
+}
+
+func (pm prometheusMetric) header(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", pm.name, pm.help, pm.name, pm.typ)
+}
+
+func (pm prometheusMetric) sample(w io.Writer, labels string, value string) {
+	fmt.Fprintf(w, "%s{%s} %s\n", pm.name, labels, value)
+}
+
+// promLabelEscaper escapes label values as the text exposition format expects - which isn't quite Go's quoting
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promLabel(name, value string) string {
+	return name + `="` + promLabelEscaper.Replace(value) + `"`
+}
+
+var (
+	promDeadLetters   = prometheusMetric{"actor_dead_letters_total", "Number of messages and events the manager failed to deliver.", "counter"}
+	promGroupTicks    = prometheusMetric{"actor_tick_group_ticks_total", "Number of times the tick group has ticked.", "counter"}
+	promGroupSkipped  = prometheusMetric{"actor_tick_group_skipped_total", "Number of actor ticks skipped via WantTick in the tick group.", "counter"}
+	promGroupDeferred = prometheusMetric{"actor_tick_group_deferred_total", "Number of actor ticks deferred to the next tick by the tick group's budget.", "counter"}
+	promGroupOverruns = prometheusMetric{"actor_tick_group_overruns_total", "Number of times the tick group exceeded its budget.", "counter"}
+	promGroupActors   = prometheusMetric{"actor_tick_group_actors", "Number of actors in the tick group.", "gauge"}
+	promGroupDuration = prometheusMetric{"actor_tick_group_duration_seconds", "Time taken to tick every actor in the tick group.", "gauge"}
+	promGroupLateness = prometheusMetric{"actor_tick_group_lateness_seconds", "Time past the tick group's interval before it ticked.", "gauge"}
+	promActorTicks    = prometheusMetric{"actor_ticks_total", "Number of times the actor has ticked.", "counter"}
+	promActorSkipped  = prometheusMetric{"actor_tick_skipped_total", "Number of ticks the actor skipped via WantTick.", "counter"}
+	promActorDeferred = prometheusMetric{"actor_tick_deferred_total", "Number of ticks the actor had deferred by its tick group's budget.", "counter"}
+	promActorDuration = prometheusMetric{"actor_tick_duration_seconds", "Time taken to tick the actor.", "gauge"}
+	promActorMailbox  = prometheusMetric{"actor_mailbox_depth", "Number of messages waiting in the actor's mailbox.", "gauge"}
+	promActorDropped  = prometheusMetric{"actor_mailbox_dropped_total", "Number of messages the actor's mailbox dropped because it was full.", "counter"}
+)
+
+func writePrometheusStats(w io.Writer, ms ManagerStats, includeActors bool) {
+	// every tick group carries the same labels, as Prometheus expects of a metric's series - those that don't apply
+	// to the group are left at their zero values (calendar groups have no interval, and interval groups no schedule)
+	groupLabels := func(tgs TickGroupStats) string {
+		return promLabel("interval", tgs.Interval.String()) + "," +
+			promLabel("phase", tgs.Phase.String()) + "," +
+			promLabel("schedule", tgs.Schedule)
+	}
+
+	promDeadLetters.header(w)
+	fmt.Fprintf(w, "%s %d\n", promDeadLetters.name, ms.DeadLetters)
+
+	promGroupTicks.header(w)
+	for _, tgs := range ms.TickGroups {
+		promGroupTicks.sample(w, groupLabels(tgs), strconv.FormatUint(tgs.TickCount, 10))
+	}
+
+	promGroupSkipped.header(w)
+	for _, tgs := range ms.TickGroups {
+		promGroupSkipped.sample(w, groupLabels(tgs), strconv.FormatUint(tgs.SkippedCount, 10))
+	}
+
+	promGroupDeferred.header(w)
+	for _, tgs := range ms.TickGroups {
+		promGroupDeferred.sample(w, groupLabels(tgs), strconv.FormatUint(tgs.DeferredCount, 10))
+	}
+
+	promGroupOverruns.header(w)
+	for _, tgs := range ms.TickGroups {
+		promGroupOverruns.sample(w, groupLabels(tgs), strconv.FormatUint(tgs.Overruns, 10))
+	}
+
+	promGroupActors.header(w)
+	for _, tgs := range ms.TickGroups {
+		promGroupActors.sample(w, groupLabels(tgs), strconv.Itoa(tgs.Actors))
+	}
+
+	promGroupDuration.header(w)
+	for _, tgs := range ms.TickGroups {
+		writePrometheusDurations(w, promGroupDuration, groupLabels(tgs), tgs.LastDuration, tgs.AvgDuration, tgs.MaxDuration)
+	}
+
+	promGroupLateness.header(w)
+	for _, tgs := range ms.TickGroups {
+		writePrometheusDurations(w, promGroupLateness, groupLabels(tgs), tgs.LastLateness, tgs.AvgLateness, tgs.MaxLateness)
+	}
+
+	if !includeActors {
+		return
+	}
+
+	actorLabels := func(as ActorStats) string {
+		return strings.Join([]string{
+			promLabel("id", strconv.FormatUint(as.ID, 10)),
+			promLabel("type", as.Type),
+			promLabel("name", as.Name),
+		}, ",")
+	}
+
+	promActorTicks.header(w)
+	for _, as := range ms.Actors {
+		promActorTicks.sample(w, actorLabels(as), strconv.FormatUint(as.TickCount, 10))
+	}
+
+	promActorSkipped.header(w)
+	for _, as := range ms.Actors {
+		promActorSkipped.sample(w, actorLabels(as), strconv.FormatUint(as.SkippedCount, 10))
+	}
+
+	promActorDeferred.header(w)
+	for _, as := range ms.Actors {
+		promActorDeferred.sample(w, actorLabels(as), strconv.FormatUint(as.DeferredCount, 10))
+	}
+
+	promActorDuration.header(w)
+	for _, as := range ms.Actors {
+		writePrometheusDurations(w, promActorDuration, actorLabels(as), as.LastDuration, as.AvgDuration, as.MaxDuration)
+	}
+
+	promActorMailbox.header(w)
+	for _, as := range ms.Actors {
+		promActorMailbox.sample(w, actorLabels(as), strconv.Itoa(as.MailboxLen))
+	}
+
+	promActorDropped.header(w)
+	for _, as := range ms.Actors {
+		promActorDropped.sample(w, actorLabels(as), strconv.FormatUint(as.MailboxDropped, 10))
+	}
+}
+
+func writePrometheusDurations(w io.Writer, pm prometheusMetric, labels string, last, avg, max time.Duration) {
+	for _, stat := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"last", last},
+		{"avg", avg},
+		{"max", max},
+	} {
+		pm.sample(w, strings.Join([]string{labels, promLabel("stat", stat.name)}, ","), strconv.FormatFloat(stat.d.Seconds(), 'g', -1, 64))
+	}
+}
@@ -0,0 +1,43 @@
+package actor
+
+import "context"
+
+// ActorRef is a location-transparent reference to an actor,
+// which may live in this process (see: Manager.Ref) or in another one (see: RemoteNode.ActorRef)
+type ActorRef interface {
+	// Tell sends a message to the actor
+	Tell(msg Message) error
+	// Ask sends a message to the actor and waits for its reply
+	Ask(ctx context.Context, msg Message) (Message, error)
+}
+
+type localRef struct {
+	m *Manager
+	a Actor
+}
+
+func (r localRef) Tell(msg Message) error {
+	return r.m.Tell(r.a, msg)
+}
+
+func (r localRef) Ask(ctx context.Context, msg Message) (Message, error) {
+	return r.m.Ask(ctx, r.a, msg)
+}
+
+// Ref returns a reference to an actor in the manager
+func (m *Manager) Ref(a Actor) ActorRef {
+	return localRef{
+		m: m,
+		a: a,
+	}
+}
+
+// RefByName returns a reference to the actor added to the manager with the Name option provided
+func (m *Manager) RefByName(name string) (ActorRef, error) {
+	a, err := m.ActorByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Ref(a), nil
+}
@@ -0,0 +1,113 @@
+package actor
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrClassNotRegistered is for when a class (type) has not been registered via RegisterClass()
+	ErrClassNotRegistered = errors.New("class not registered")
+
+	// ErrClassAlreadyRegistered is for when a class name or type has already been registered via RegisterClass()
+	ErrClassAlreadyRegistered = errors.New("class already registered")
+)
+
+type classRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+var classes = classRegistry{
+	byName: make(map[string]reflect.Type),
+	byType: make(map[reflect.Type]string),
+}
+
+// RegisterClass registers a type under a unique name, so that it may be re-created later by name
+// (e.g.: when restoring a Snapshot). The type should be the same one passed to SpawnActor()
+func RegisterClass(name string, typ reflect.Type) error {
+	typ = classType(typ)
+
+	classes.mu.Lock()
+	defer classes.mu.Unlock()
+
+	if _, found := classes.byName[name]; found {
+		return errors.Wrapf(ErrClassAlreadyRegistered, "name %q", name)
+	}
+	if _, found := classes.byType[typ]; found {
+		return errors.Wrapf(ErrClassAlreadyRegistered, "type %v", typ)
+	}
+
+	classes.byName[name] = typ
+	classes.byType[typ] = name
+	return nil
+}
+
+// ClassByName returns the type registered under the name provided
+func ClassByName(name string) (reflect.Type, error) {
+	classes.mu.RLock()
+	defer classes.mu.RUnlock()
+
+	typ, found := classes.byName[name]
+	if !found {
+		return nil, errors.Wrapf(ErrClassNotRegistered, "name %q", name)
+	}
+	return typ, nil
+}
+
+// ClassName returns the registered name of the class of the value provided
+func ClassName(v interface{}) (string, error) {
+	typ := classType(reflect.TypeOf(v))
+
+	classes.mu.RLock()
+	defer classes.mu.RUnlock()
+
+	name, found := classes.byType[typ]
+	if !found {
+		return "", errors.Wrapf(ErrClassNotRegistered, "type %v", typ)
+	}
+	return name, nil
+}
+
+// classType strips the pointer off of a type, as SpawnActor() creates pointers to the class type
+func classType(typ reflect.Type) reflect.Type {
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
+
+// marshalClass encodes a value of a registered class, returning its class name and JSON encoding
+func marshalClass(v interface{}) (string, []byte, error) {
+	class, err := ClassName(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return class, data, nil
+}
+
+// unmarshalClass re-creates a value encoded via marshalClass
+// NOTE: values are always re-created as values of their registered class, never as pointers
+func unmarshalClass(class string, data []byte) (interface{}, error) {
+	typ, err := ClassByName(class)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.New(typ)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+
+	return v.Elem().Interface(), nil
+}
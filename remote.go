@@ -0,0 +1,422 @@
+package actor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrRemoteNodeClosed is for when a connection to a remote node has been closed
+	ErrRemoteNodeClosed = errors.New("remote node closed")
+)
+
+type wireKind string
+
+const (
+	wireLookup = wireKind("lookup")
+	wireTell   = wireKind("tell")
+	wireAsk    = wireKind("ask")
+	wireReply  = wireKind("reply")
+)
+
+// wireFrame is a single request or reply sent between nodes
+// messages are encoded by their registered class name (see: RegisterClass) and JSON
+type wireFrame struct {
+	Kind      wireKind `json:"kind"`
+	ID        uint64   `json:"id,omitempty"`
+	Target    string   `json:"target,omitempty"`
+	Class     string   `json:"class,omitempty"`
+	Payload   []byte   `json:"payload,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	ErrorCode string   `json:"errorCode,omitempty"`
+}
+
+// wireErrors are the errors that keep their identity when crossing the wire
+var wireErrors = []error{
+	ErrActorNotFound,
+	ErrActorCannotRespond,
+	ErrManagerStopped,
+}
+
+func (f *wireFrame) setError(err error) {
+	if err == nil {
+		return
+	}
+
+	f.Error = err.Error()
+	for _, werr := range wireErrors {
+		if errors.Is(err, werr) {
+			f.ErrorCode = werr.Error()
+			break
+		}
+	}
+}
+
+func (f wireFrame) err() error {
+	if f.Error == "" {
+		return nil
+	}
+
+	for _, werr := range wireErrors {
+		if f.ErrorCode == werr.Error() {
+			return &remoteError{
+				msg:   f.Error,
+				cause: werr,
+			}
+		}
+	}
+	return errors.New(f.Error)
+}
+
+type remoteError struct {
+	msg   string
+	cause error
+}
+
+func (e *remoteError) Error() string {
+	return e.msg
+}
+
+func (e *remoteError) Cause() error {
+	return e.cause
+}
+
+func (e *remoteError) Unwrap() error {
+	return e.cause
+}
+
+// Node exposes a manager's named actors (see: Name) to other processes over TCP
+type Node struct {
+	m  *Manager
+	ln net.Listener
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+// Listen starts a node that exposes the manager's named actors on the TCP address provided
+func (m *Manager) Listen(addr string) (*Node, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	n := Node{
+		m:     m,
+		ln:    ln,
+		conns: make(map[net.Conn]struct{}),
+	}
+
+	n.wg.Add(1)
+	go n.acceptLoop()
+
+	return &n, nil
+}
+
+// Addr returns the address the node is listening on
+func (n *Node) Addr() net.Addr {
+	return n.ln.Addr()
+}
+
+// Close stops the node from listening and closes all its connections
+func (n *Node) Close() error {
+	err := n.ln.Close()
+
+	n.mu.Lock()
+	for conn := range n.conns {
+		conn.Close()
+	}
+	n.mu.Unlock()
+
+	n.wg.Wait()
+	return err
+}
+
+func (n *Node) acceptLoop() {
+	defer n.wg.Done()
+
+	for {
+		conn, err := n.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		n.mu.Lock()
+		n.conns[conn] = struct{}{}
+		n.mu.Unlock()
+
+		n.wg.Add(1)
+		go n.serve(conn)
+	}
+}
+
+func (n *Node) serve(conn net.Conn) {
+	defer n.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		conn.Close()
+
+		n.mu.Lock()
+		delete(n.conns, conn)
+		n.mu.Unlock()
+	}()
+
+	var wmu sync.Mutex
+	enc := json.NewEncoder(conn)
+	reply := func(f wireFrame) {
+		f.Kind = wireReply
+
+		wmu.Lock()
+		defer wmu.Unlock()
+		enc.Encode(f)
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var f wireFrame
+		if err := dec.Decode(&f); err != nil {
+			return
+		}
+
+		switch f.Kind {
+		case wireLookup:
+			_, err := n.m.ActorByName(f.Target)
+			r := wireFrame{ID: f.ID}
+			r.setError(err)
+			reply(r)
+
+		case wireTell:
+			// fire and forget - anything that can't be delivered goes to the dead letters, and the connection isn't
+			// held up waiting on a full mailbox
+			a, msg, err := n.resolve(f)
+			if err != nil {
+				n.m.undeliverable(a, []envelope{{msg: msg}}, err)
+				continue
+			}
+			_ = n.m.tryTell(a, envelope{msg: msg})
+
+		case wireAsk:
+			// Close() waits for asks in flight, which are cancelled once the connection closes
+			n.wg.Add(1)
+			go func(f wireFrame) {
+				defer n.wg.Done()
+
+				r := wireFrame{ID: f.ID}
+				defer func() {
+					reply(r)
+				}()
+
+				a, msg, err := n.resolve(f)
+				if err != nil {
+					r.setError(err)
+					return
+				}
+
+				answer, err := n.m.Ask(ctx, a, msg)
+				if err != nil {
+					r.setError(err)
+					return
+				}
+
+				if answer != nil {
+					r.Class, r.Payload, err = marshalClass(answer)
+					r.setError(err)
+				}
+			}(f)
+		}
+	}
+}
+
+// resolve finds the target of the frame and decodes its message - whichever of the two it manages to, if it fails
+func (n *Node) resolve(f wireFrame) (Actor, Message, error) {
+	msg, err := unmarshalClass(f.Class, f.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := n.m.ActorByName(f.Target)
+	if err != nil {
+		return nil, msg, err
+	}
+
+	return a, msg, nil
+}
+
+// RemoteNode is a connection to a Node in another process
+type RemoteNode struct {
+	conn net.Conn
+
+	wmu sync.Mutex
+	enc *json.Encoder
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan wireFrame
+	closed  bool
+}
+
+// DialNode connects to a Node listening on the TCP address provided
+func DialNode(ctx context.Context, addr string) (*RemoteNode, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	r := RemoteNode{
+		conn:    conn,
+		enc:     json.NewEncoder(conn),
+		pending: make(map[uint64]chan wireFrame),
+	}
+
+	go r.readLoop()
+
+	return &r, nil
+}
+
+// Close closes the connection to the remote node
+func (r *RemoteNode) Close() error {
+	return r.conn.Close()
+}
+
+// ActorRef returns a reference to the actor with the name provided on the remote node
+func (r *RemoteNode) ActorRef(ctx context.Context, name string) (ActorRef, error) {
+	f, err := r.request(ctx, wireFrame{
+		Kind:   wireLookup,
+		Target: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.err(); err != nil {
+		return nil, err
+	}
+
+	ref := remoteRef{
+		r:    r,
+		name: name,
+	}
+
+	return ref, nil
+}
+
+func (r *RemoteNode) send(f wireFrame) error {
+	r.wmu.Lock()
+	defer r.wmu.Unlock()
+
+	return r.enc.Encode(f)
+}
+
+func (r *RemoteNode) request(ctx context.Context, f wireFrame) (wireFrame, error) {
+	ch := make(chan wireFrame, 1)
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return wireFrame{}, ErrRemoteNodeClosed
+	}
+	r.nextID++
+	f.ID = r.nextID
+	r.pending[f.ID] = ch
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, f.ID)
+		r.mu.Unlock()
+	}()
+
+	if err := r.send(f); err != nil {
+		return wireFrame{}, err
+	}
+
+	select {
+	case reply, ok := <-ch:
+		if !ok {
+			return wireFrame{}, ErrRemoteNodeClosed
+		}
+		return reply, nil
+	case <-ctx.Done():
+		return wireFrame{}, ctx.Err()
+	}
+}
+
+func (r *RemoteNode) readLoop() {
+	defer func() {
+		r.mu.Lock()
+		r.closed = true
+		for id, ch := range r.pending {
+			close(ch)
+			delete(r.pending, id)
+		}
+		r.mu.Unlock()
+	}()
+
+	dec := json.NewDecoder(bufio.NewReader(r.conn))
+	for {
+		var f wireFrame
+		if err := dec.Decode(&f); err != nil {
+			return
+		}
+
+		r.mu.Lock()
+		if ch, ok := r.pending[f.ID]; ok {
+			ch <- f
+		}
+		r.mu.Unlock()
+	}
+}
+
+type remoteRef struct {
+	r    *RemoteNode
+	name string
+}
+
+func (ref remoteRef) Tell(msg Message) error {
+	class, payload, err := marshalClass(msg)
+	if err != nil {
+		return err
+	}
+
+	return ref.r.send(wireFrame{
+		Kind:    wireTell,
+		Target:  ref.name,
+		Class:   class,
+		Payload: payload,
+	})
+}
+
+func (ref remoteRef) Ask(ctx context.Context, msg Message) (Message, error) {
+	class, payload, err := marshalClass(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := ref.r.request(ctx, wireFrame{
+		Kind:    wireAsk,
+		Target:  ref.name,
+		Class:   class,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.err(); err != nil {
+		return nil, err
+	}
+
+	if f.Class == "" {
+		return nil, nil
+	}
+	return unmarshalClass(f.Class, f.Payload)
+}
@@ -0,0 +1,204 @@
+package actor_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type remotePing struct {
+	N int
+}
+
+type remotePong struct {
+	N int
+}
+
+type remoteEchoTest struct {
+	forward actor.ActorRef
+}
+
+func (a *remoteEchoTest) Respond(msg actor.Message) (actor.Message, error) {
+	ping := msg.(remotePing)
+	return remotePong{N: ping.N + 1}, nil
+}
+
+func (a *remoteEchoTest) Receive(msg actor.Message) error {
+	return a.forward.Tell(msg)
+}
+
+type remoteCollectorTest struct {
+	received chan actor.Message
+}
+
+func (a *remoteCollectorTest) Receive(msg actor.Message) error {
+	a.received <- msg
+	return nil
+}
+
+func init() {
+	if err := actor.RegisterClass("remotePing", reflect.TypeOf(remotePing{})); err != nil {
+		panic(err)
+	}
+	if err := actor.RegisterClass("remotePong", reflect.TypeOf(remotePong{})); err != nil {
+		panic(err)
+	}
+}
+
+func listenRemoteTest(t *testing.T, a actor.Actor, name string) *actor.Node {
+	t.Helper()
+
+	m := startTestManager(t)
+
+	if err := m.AddActor(a, actor.TickInterval(time.Hour), actor.Name(name)); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := m.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		n.Close()
+	})
+	return n
+}
+
+func dialRemoteTest(t *testing.T, n *actor.Node) *actor.RemoteNode {
+	t.Helper()
+
+	r, err := actor.DialNode(context.Background(), n.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+	})
+	return r
+}
+
+func TestRemoteActors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collector := &remoteCollectorTest{
+		received: make(chan actor.Message, 1),
+	}
+	nodeB := listenRemoteTest(t, collector, "collector")
+
+	forward, err := dialRemoteTest(t, nodeB).ActorRef(ctx, "collector")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeA := listenRemoteTest(t, &remoteEchoTest{forward: forward}, "echo")
+
+	echo, err := dialRemoteTest(t, nodeA).ActorRef(ctx, "echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := echo.Ask(ctx, remotePing{N: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pong, ok := reply.(remotePong); !ok || pong.N != 2 {
+		t.Fatalf("expected remotePong{2}, got %#v", reply)
+	}
+
+	// echo forwards Tell'd messages over to the collector on the other manager
+	if err := echo.Tell(remotePing{N: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-collector.received:
+		if ping, ok := msg.(remotePing); !ok || ping.N != 5 {
+			t.Fatalf("expected remotePing{5}, got %#v", msg)
+		}
+	case <-ctx.Done():
+		t.Fatal("message not forwarded")
+	}
+}
+
+func TestRemoteActorNotFound(t *testing.T) {
+	n := listenRemoteTest(t, &remoteCollectorTest{}, "collector")
+
+	if _, err := dialRemoteTest(t, n).ActorRef(context.Background(), "nobody"); !errors.Is(err, actor.ErrActorNotFound) {
+		t.Fatalf("expected ErrActorNotFound, got %v", err)
+	}
+}
+
+func TestRemoteTellDeadLetters(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a := newMailboxActorTest()
+	m := startMailboxTest(t, a, actor.Name("held"), actor.BoundedMailbox(1, actor.OverflowBlock))
+	gone := &remoteCollectorTest{}
+	if err := m.AddActor(gone, actor.TickInterval(time.Hour), actor.Name("gone")); err != nil {
+		t.Fatal(err)
+	}
+
+	dead, unsubscribe := m.SubscribeDeadLetters(4)
+	defer unsubscribe()
+
+	n, err := m.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+	r := dialRemoteTest(t, n)
+
+	held, err := r.ActorRef(ctx, "held")
+	if err != nil {
+		t.Fatal(err)
+	}
+	goneRef, err := r.ActorRef(ctx, "gone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RemoveActor(gone, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// fill the blocking mailbox, so that a remote Tell() can't be delivered
+	a.hold(t, m)
+	defer close(a.gate)
+	if err := m.Tell(a, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := held.Tell(remotePing{N: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := goneRef.Tell(remotePing{N: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []struct {
+		msg    actor.Message
+		reason error
+	}{
+		{remotePing{N: 1}, actor.ErrMailboxFull},
+		{remotePing{N: 2}, actor.ErrActorNotFound},
+	} {
+		select {
+		case dl := <-dead:
+			if dl.Message != expected.msg || !errors.Is(dl.Reason, expected.reason) {
+				t.Fatalf("expected %v to be undeliverable with %v, got %v with %v", expected.msg, expected.reason, dl.Message, dl.Reason)
+			}
+		case <-ctx.Done():
+			t.Fatalf("expected %v to be undeliverable", expected.msg)
+		}
+	}
+
+	// the connection wasn't held up by the full mailbox
+	if _, err := r.ActorRef(ctx, "held"); err != nil {
+		t.Fatal(err)
+	}
+}
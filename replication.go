@@ -0,0 +1,482 @@
+package actor
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrReplicatedActorRemoved is the EndPlay reason given to a proxy actor when the server removes the actor it represents
+	ErrReplicatedActorRemoved = errors.New("replicated actor removed")
+)
+
+// ReplicatedActorDelta is the set of changes to a single replicated actor
+type ReplicatedActorDelta struct {
+	ID      uint64                     `json:"id"`
+	Class   string                     `json:"class,omitempty"`
+	Fields  map[string]json.RawMessage `json:"fields,omitempty"`
+	Removed bool                       `json:"removed,omitempty"`
+}
+
+// ReplicationUpdate is a batch of changes sent from a server manager to a client manager
+type ReplicationUpdate struct {
+	Actors []ReplicatedActorDelta `json:"actors"`
+}
+
+// ReplicationSink receives replication updates from a Replicator
+type ReplicationSink interface {
+	SendReplication(u *ReplicationUpdate) error
+}
+
+type replicatedField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+var replicatedFieldsCache sync.Map
+
+// replicatedFields returns the exported fields of an actor's class tagged with `actor:"replicated"`
+func replicatedFields(typ reflect.Type) []replicatedField {
+	typ = classType(typ)
+	if cached, ok := replicatedFieldsCache.Load(typ); ok {
+		return cached.([]replicatedField)
+	}
+
+	var fields []replicatedField
+	if typ.Kind() == reflect.Struct {
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.PkgPath != "" {
+				// unexported
+				continue
+			}
+
+			for _, opt := range strings.Split(f.Tag.Get("actor"), ",") {
+				if opt == "replicated" {
+					fields = append(fields, replicatedField{
+						name:  f.Name,
+						index: f.Index,
+						typ:   f.Type,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	replicatedFieldsCache.Store(typ, fields)
+	return fields
+}
+
+type replicationSubscription struct {
+	sink     ReplicationSink
+	relevant func(a Actor) bool
+	sent     map[uint64]map[string]string
+}
+
+// Replicator sends the replicated fields of a server manager's actors to subscribed clients
+// fields are marked as replicated with the `actor:"replicated"` struct tag, and actors must be of a class
+// registered with RegisterClass(). Changes are diffed after each tick group has ticked
+type Replicator struct {
+	m *Manager
+
+	mu         sync.Mutex
+	subs       []*replicationSubscription
+	lastUpdate map[uint64]time.Time
+
+	removedMu sync.Mutex
+	removed   []uint64
+}
+
+// NewReplicator creates a new replicator for the server manager provided
+func NewReplicator(m *Manager) *Replicator {
+	r := Replicator{
+		m:          m,
+		lastUpdate: make(map[uint64]time.Time),
+	}
+
+	m.mu.Lock()
+	m.tickGroupHooks = append(m.tickGroupHooks, r.replicate)
+	m.removedHooks = append(m.removedHooks, r.actorRemoved)
+	m.mu.Unlock()
+
+	return &r
+}
+
+// Subscribe starts sending updates to the sink provided
+// if relevant is not nil, only actors it reports as relevant are replicated to the sink
+func (r *Replicator) Subscribe(sink ReplicationSink, relevant func(a Actor) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subs = append(r.subs, &replicationSubscription{
+		sink:     sink,
+		relevant: relevant,
+		sent:     make(map[uint64]map[string]string),
+	})
+}
+
+// Unsubscribe stops sending updates to the sink provided
+func (r *Replicator) Unsubscribe(sink ReplicationSink) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.unsubscribe(sink)
+}
+
+func (r *Replicator) unsubscribe(sink ReplicationSink) {
+	for i, sub := range r.subs {
+		if sub.sink == sink {
+			r.subs = append(r.subs[:i], r.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// actorRemoved is called with the manager's lock held, so it defers the work to the tick goroutine
+func (r *Replicator) actorRemoved(a Actor, id uint64) {
+	r.removedMu.Lock()
+	r.removed = append(r.removed, id)
+	r.removedMu.Unlock()
+
+	r.m.post(func() {
+		r.replicate(nil)
+	})
+}
+
+func (r *Replicator) replicate(actors []Actor) {
+	now := r.m.currentClock().Now()
+
+	r.removedMu.Lock()
+	removed := r.removed
+	r.removed = nil
+	r.removedMu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updates := make([]ReplicationUpdate, len(r.subs))
+
+	for _, id := range removed {
+		delete(r.lastUpdate, id)
+		for i, sub := range r.subs {
+			if _, known := sub.sent[id]; known {
+				delete(sub.sent, id)
+				updates[i].Actors = append(updates[i].Actors, ReplicatedActorDelta{
+					ID:      id,
+					Removed: true,
+				})
+			}
+		}
+	}
+
+	for _, a := range actors {
+		if reflect.TypeOf(a).Kind() != reflect.Ptr {
+			continue
+		}
+
+		fields := replicatedFields(reflect.TypeOf(a))
+		if len(fields) == 0 {
+			continue
+		}
+
+		class, err := ClassName(a)
+		if err != nil {
+			continue
+		}
+
+		id, found := r.m.actorID(a)
+		if !found {
+			continue
+		}
+
+		if freq := NetUpdateFrequency(a); freq > 0 {
+			if now.Sub(r.lastUpdate[id]) < time.Duration(float64(time.Second)/freq) {
+				continue
+			}
+		}
+		r.lastUpdate[id] = now
+
+		v := reflect.ValueOf(a).Elem()
+		values := make(map[string]string)
+		for _, f := range fields {
+			data, err := json.Marshal(v.FieldByIndex(f.index).Interface())
+			if err != nil {
+				continue
+			}
+			values[f.name] = string(data)
+		}
+
+		for i, sub := range r.subs {
+			sent, known := sub.sent[id]
+			if sub.relevant != nil && !sub.relevant(a) {
+				if known {
+					delete(sub.sent, id)
+					updates[i].Actors = append(updates[i].Actors, ReplicatedActorDelta{
+						ID:      id,
+						Removed: true,
+					})
+				}
+				continue
+			}
+
+			delta := ReplicatedActorDelta{
+				ID:     id,
+				Fields: make(map[string]json.RawMessage),
+			}
+			if !known {
+				delta.Class = class
+				sent = make(map[string]string)
+				sub.sent[id] = sent
+			}
+
+			for name, value := range values {
+				if sent[name] != value {
+					delta.Fields[name] = json.RawMessage(value)
+					sent[name] = value
+				}
+			}
+
+			if !known || len(delta.Fields) > 0 {
+				updates[i].Actors = append(updates[i].Actors, delta)
+			}
+		}
+	}
+
+	subs := append([]*replicationSubscription(nil), r.subs...)
+	for i, sub := range subs {
+		if len(updates[i].Actors) == 0 {
+			continue
+		}
+
+		if err := sub.sink.SendReplication(&updates[i]); err != nil {
+			// the client has gone away
+			r.unsubscribe(sub.sink)
+		}
+	}
+}
+
+type connReplicationSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (s *connReplicationSink) SendReplication(u *ReplicationUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(u)
+}
+
+// NewConnReplicationSink creates a ReplicationSink that writes updates to a connection,
+// to be read on the other end by ReplicationClient.ServeConn()
+func NewConnReplicationSink(w io.Writer) ReplicationSink {
+	s := connReplicationSink{
+		enc: json.NewEncoder(w),
+	}
+
+	return &s
+}
+
+// ReplicationClient applies replication updates to proxy actors in a client manager
+// proxies are spawned via SpawnActor() the first time an actor is replicated,
+// and updates to existing proxies are applied on the client manager's tick goroutine
+type ReplicationClient struct {
+	m    *Manager
+	opts []Option
+
+	mu      sync.Mutex
+	proxies map[uint64]Actor
+}
+
+// NewReplicationClient creates a new replication client for the client manager provided
+// proxies are added to the manager with the Options provided
+func NewReplicationClient(m *Manager, opts ...Option) *ReplicationClient {
+	c := ReplicationClient{
+		m:       m,
+		opts:    opts,
+		proxies: make(map[uint64]Actor),
+	}
+
+	return &c
+}
+
+// Proxy returns the proxy actor for the server actor ID provided
+func (c *ReplicationClient) Proxy(id uint64) (Actor, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, found := c.proxies[id]
+	return a, found
+}
+
+// SendReplication applies the update in-process, which allows the client to subscribe directly to a Replicator
+func (c *ReplicationClient) SendReplication(u *ReplicationUpdate) error {
+	return c.Apply(u)
+}
+
+// ServeConn reads updates written by a sink from NewConnReplicationSink() and applies them, until the connection closes
+func (c *ReplicationClient) ServeConn(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var u ReplicationUpdate
+		if err := dec.Decode(&u); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := c.Apply(&u); err != nil {
+			return err
+		}
+	}
+}
+
+// Apply applies a replication update to the proxy actors
+func (c *ReplicationClient) Apply(u *ReplicationUpdate) error {
+	for _, d := range u.Actors {
+		c.mu.Lock()
+		proxy, known := c.proxies[d.ID]
+		if known && d.Removed {
+			delete(c.proxies, d.ID)
+		}
+		c.mu.Unlock()
+
+		if d.Removed {
+			if known {
+				if err := c.m.RemoveActor(proxy, ErrReplicatedActorRemoved); err != nil && !errors.Is(err, ErrActorNotFound) {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !known {
+			if err := c.spawnProxy(d); err != nil {
+				return err
+			}
+			continue
+		}
+
+		apply, names, err := decodeReplicatedFields(proxy, d.Fields)
+		if err != nil {
+			return err
+		}
+
+		delta := d
+		c.m.post(func() {
+			c.m.mu.RLock()
+			_, found := c.m.actors[proxy]
+			c.m.mu.RUnlock()
+			if !found {
+				// removed from the client manager while the update was in flight
+				c.m.undeliverable(proxy, []envelope{{
+					msg:  delta,
+					kind: DeadLetterEvent,
+				}}, ErrActorNotFound)
+				return
+			}
+
+			apply()
+			if err := OnReplicated(proxy, names); err != nil {
+				c.m.ActorLogger(proxy).Error("proxy actor failed to handle replication", slog.Any("error", err))
+			}
+		})
+	}
+
+	return nil
+}
+
+func (c *ReplicationClient) spawnProxy(d ReplicatedActorDelta) error {
+	typ, err := ClassByName(d.Class)
+	if err != nil {
+		return err
+	}
+
+	opts := []SpawnActorOption{
+		DeferredSpawnActor(),
+	}
+
+	proxy, err := SpawnActor(typ, opts...)
+	if err != nil {
+		return err
+	}
+
+	apply, names, err := decodeReplicatedFields(proxy, d.Fields)
+	if err != nil {
+		_ = DestroyActor(proxy)
+		return err
+	}
+	apply()
+
+	if err := FinishSpawningActor(proxy, opts...); err != nil {
+		return err
+	}
+
+	if err := OnReplicated(proxy, names); err != nil {
+		_ = DestroyActor(proxy)
+		return err
+	}
+
+	if err := c.m.AddActor(proxy, c.opts...); err != nil {
+		_ = DestroyActor(proxy)
+		return err
+	}
+
+	c.mu.Lock()
+	c.proxies[d.ID] = proxy
+	c.mu.Unlock()
+	return nil
+}
+
+// decodeReplicatedFields decodes the field values up front, returning a function that assigns them to the proxy
+func decodeReplicatedFields(proxy Actor, fields map[string]json.RawMessage) (func(), []string, error) {
+	byName := make(map[string]replicatedField)
+	for _, f := range replicatedFields(reflect.TypeOf(proxy)) {
+		byName[f.name] = f
+	}
+
+	type assignment struct {
+		f     replicatedField
+		value reflect.Value
+	}
+
+	var (
+		assignments []assignment
+		names       []string
+	)
+	for name, data := range fields {
+		f, ok := byName[name]
+		if !ok {
+			continue
+		}
+
+		value := reflect.New(f.typ)
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, nil, err
+		}
+
+		assignments = append(assignments, assignment{
+			f:     f,
+			value: value.Elem(),
+		})
+		names = append(names, name)
+	}
+
+	apply := func() {
+		v := reflect.ValueOf(proxy).Elem()
+		for _, as := range assignments {
+			v.FieldByIndex(as.f.index).Set(as.value)
+		}
+	}
+
+	return apply, names, nil
+}
@@ -0,0 +1,132 @@
+package actor_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type replicationActorTest struct {
+	Position int `actor:"replicated"`
+	Secret   int
+
+	updates chan replicationActorTest
+}
+
+func (a *replicationActorTest) Tick(deltaTime time.Duration) error {
+	a.Position++
+	a.Secret++
+	return nil
+}
+
+func (a *replicationActorTest) OnReplicated(fields []string) error {
+	select {
+	case a.updates <- *a:
+	default:
+	}
+	return nil
+}
+
+func (a *replicationActorTest) PostSpawnInitialize() error {
+	a.updates = make(chan replicationActorTest, 1)
+	return nil
+}
+
+func init() {
+	if err := actor.RegisterClass("replicationActorTest", reflect.TypeOf(replicationActorTest{})); err != nil {
+		panic(err)
+	}
+}
+
+func waitForProxy(t *testing.T, c *actor.ReplicationClient, id uint64) *replicationActorTest {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if proxy, found := c.Proxy(id); found {
+			return proxy.(*replicationActorTest)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("proxy %d never spawned", id)
+	return nil
+}
+
+func testReplication(t *testing.T, server *actor.Manager, c *actor.ReplicationClient) {
+	a, err := actor.SpawnActor(reflect.TypeOf(replicationActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.AddActor(a, actor.TickInterval(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the first actor added to a manager gets ID 1
+	proxy := waitForProxy(t, c, 1)
+
+	for seen := 0; seen < 3; seen++ {
+		select {
+		case p := <-proxy.updates:
+			if p.Position == 0 {
+				t.Fatal("expected Position to be replicated")
+			}
+			if p.Secret != 0 {
+				t.Fatalf("expected Secret to not be replicated, got %d", p.Secret)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("proxy not updated")
+		}
+	}
+
+	if err := server.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, found := c.Proxy(1); !found {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("proxy not removed")
+}
+
+func TestReplicationInProcess(t *testing.T) {
+	server := startTestManager(t)
+	r := actor.NewReplicator(server)
+
+	c := actor.NewReplicationClient(startTestManager(t), actor.TickInterval(time.Hour))
+	r.Subscribe(c, nil)
+
+	irrelevant := actor.NewReplicationClient(startTestManager(t), actor.TickInterval(time.Hour))
+	r.Subscribe(irrelevant, func(a actor.Actor) bool {
+		return false
+	})
+
+	testReplication(t, server, c)
+
+	if _, found := irrelevant.Proxy(1); found {
+		t.Fatal("expected irrelevant actor to not be replicated")
+	}
+}
+
+func TestReplicationOverConn(t *testing.T) {
+	server := startTestManager(t)
+	r := actor.NewReplicator(server)
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	c := actor.NewReplicationClient(startTestManager(t), actor.TickInterval(time.Hour))
+	go c.ServeConn(clientConn)
+
+	r.Subscribe(actor.NewConnReplicationSink(serverConn), nil)
+
+	testReplication(t, server, c)
+}
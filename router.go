@@ -0,0 +1,487 @@
+package actor
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidRouterSize is for when a router is given a worker count (or resize bounds) it can't have
+	ErrInvalidRouterSize = errors.New("invalid router size")
+
+	// ErrInvalidRoutingStrategy is for when a router is given a routing strategy it doesn't know
+	ErrInvalidRoutingStrategy = errors.New("invalid routing strategy")
+
+	// ErrMessageNotHashable is for when a consistent-hashing router is sent a message that doesn't implement
+	// ConsistentHashableIntf
+	ErrMessageNotHashable = errors.New("message not hashable")
+
+	// ErrRouterWorkerRemoved is the reason given to workers that a router removes when it shrinks
+	ErrRouterWorkerRemoved = errors.New("router worker removed")
+)
+
+// RoutingStrategy is how a router picks the worker(s) a message goes to
+type RoutingStrategy int
+
+const (
+	// RouteRoundRobin sends each message to the next worker in turn
+	RouteRoundRobin = RoutingStrategy(iota)
+	// RouteRandom sends each message to a worker picked at random
+	RouteRandom
+	// RouteSmallestMailbox sends each message to the worker with the fewest messages waiting in its mailbox
+	RouteSmallestMailbox
+	// RouteConsistentHashing sends messages with the same key (see: ConsistentHashableIntf) to the same worker, with
+	// as few keys as possible moving to another worker when the router is resized
+	RouteConsistentHashing
+	// RouteBroadcast sends each message to every worker
+	RouteBroadcast
+)
+
+func (s RoutingStrategy) String() string {
+	switch s {
+	case RouteRoundRobin:
+		return "RoundRobin"
+	case RouteRandom:
+		return "Random"
+	case RouteSmallestMailbox:
+		return "SmallestMailbox"
+	case RouteConsistentHashing:
+		return "ConsistentHashing"
+	case RouteBroadcast:
+		return "Broadcast"
+	default:
+		return fmt.Sprintf("RoutingStrategy(%d)", int(s))
+	}
+}
+
+// ConsistentHashableIntf is for messages sent to a consistent-hashing router, which routes them by their key
+type ConsistentHashableIntf interface {
+	ConsistentHashKey() string
+}
+
+// DefaultRouterResizeInterval is the default amount of time between a resizable router's checks of its workers' load
+const DefaultRouterResizeInterval = time.Second
+
+// DefaultRouterResizeBacklog is the default number of messages waiting per worker above which a resizable router grows
+const DefaultRouterResizeBacklog = 8
+
+// routerVirtualNodes is the number of points each worker has on a consistent-hashing router's ring
+const routerVirtualNodes = 64
+
+type routerSettings struct {
+	strategy       RoutingStrategy
+	size           int
+	minSize        int
+	maxSize        int
+	resizeInterval time.Duration
+	resizeBacklog  int
+	workerSpawn    []SpawnActorOption
+	workerOpts     []Option
+	routerOpts     []Option
+}
+
+// resizable reports if the router resizes itself based on load
+func (s routerSettings) resizable() bool {
+	return s.maxSize > s.minSize
+}
+
+// RouterOption is a function that sets up an option during the NewRouter function
+type RouterOption func(*routerSettings) error
+
+// Routing sets how the router picks the worker(s) a message goes to - RouteRoundRobin by default
+func Routing(strategy RoutingStrategy) RouterOption {
+	return func(s *routerSettings) error {
+		if strategy < RouteRoundRobin || strategy > RouteBroadcast {
+			return errors.Wrapf(ErrInvalidRoutingStrategy, "%v", strategy)
+		}
+
+		s.strategy = strategy
+		return nil
+	}
+}
+
+// RouterSize sets the number of workers the router starts with - one by default
+func RouterSize(n int) RouterOption {
+	return func(s *routerSettings) error {
+		if n < 1 {
+			return errors.Wrapf(ErrInvalidRouterSize, "%d", n)
+		}
+
+		s.size = n
+		return nil
+	}
+}
+
+// ResizableRouter lets the router grow to as many as max workers while its workers' mailboxes are backed up, and
+// shrink to as few as min workers while they're empty (see: RouterResizeBacklog())
+func ResizableRouter(min int, max int) RouterOption {
+	return func(s *routerSettings) error {
+		if min < 1 || max < min {
+			return errors.Wrapf(ErrInvalidRouterSize, "%d-%d", min, max)
+		}
+
+		s.minSize = min
+		s.maxSize = max
+		return nil
+	}
+}
+
+// RouterResizeInterval sets how often a resizable router checks its workers' load - DefaultRouterResizeInterval by default
+func RouterResizeInterval(interval time.Duration) RouterOption {
+	return func(s *routerSettings) error {
+		if interval == time.Duration(0) {
+			return ErrTickIntervalCannotBeZero
+		}
+
+		s.resizeInterval = interval
+		return nil
+	}
+}
+
+// RouterResizeBacklog sets the average number of messages waiting in each worker's mailbox above which a resizable
+// router adds a worker - DefaultRouterResizeBacklog by default
+func RouterResizeBacklog(n int) RouterOption {
+	return func(s *routerSettings) error {
+		if n < 1 {
+			return errors.Wrapf(ErrInvalidRouterSize, "backlog %d", n)
+		}
+
+		s.resizeBacklog = n
+		return nil
+	}
+}
+
+// WorkerSpawnOptions sets the options the router's workers are spawned with, via SpawnActor()
+func WorkerSpawnOptions(opts ...SpawnActorOption) RouterOption {
+	return func(s *routerSettings) error {
+		s.workerSpawn = opts
+		return nil
+	}
+}
+
+// WorkerOptions sets the options the router's workers are added to the manager with, via AddActor()
+func WorkerOptions(opts ...Option) RouterOption {
+	return func(s *routerSettings) error {
+		s.workerOpts = opts
+		return nil
+	}
+}
+
+// RouterActorOptions sets the options the router itself is added to the manager with, via AddActor() - e.g.: Name()
+func RouterActorOptions(opts ...Option) RouterOption {
+	return func(s *routerSettings) error {
+		s.routerOpts = opts
+		return nil
+	}
+}
+
+// Router is an actor that spreads the messages sent to it (see: Manager.Tell()) across a set of worker actors, which
+// it spawns via SpawnActor() and adds to its manager - and removes from it again when the router is removed
+// messages sent via Manager.Ask() are answered by the chosen worker's Respond() right away, bypassing its mailbox
+// NOTE: workers' mailboxes must not use OverflowBlock, as the router forwards messages on the manager's tick goroutine
+type Router struct {
+	m        *Manager
+	typ      reflect.Type
+	settings routerSettings
+
+	// resizeMu serializes resizing, which spawns and removes workers outside of mu
+	resizeMu sync.Mutex
+
+	mu      sync.Mutex
+	workers []routerWorker
+	nextID  uint64
+	next    int
+	ring    []routerRingPoint
+}
+
+type routerWorker struct {
+	a  Actor
+	id uint64
+}
+
+type routerRingPoint struct {
+	hash   uint64
+	worker Actor
+}
+
+// NewRouter creates a router of workers of the type provided (the same type that would be passed to SpawnActor()),
+// and adds it and its workers to the manager
+func NewRouter(m *Manager, typ reflect.Type, opts ...RouterOption) (*Router, error) {
+	s := routerSettings{
+		size:           1,
+		resizeInterval: DefaultRouterResizeInterval,
+		resizeBacklog:  DefaultRouterResizeBacklog,
+	}
+	for _, opt := range opts {
+		if err := opt(&s); err != nil {
+			return nil, err
+		}
+	}
+	if s.resizable() {
+		if s.size < s.minSize {
+			s.size = s.minSize
+		} else if s.size > s.maxSize {
+			s.size = s.maxSize
+		}
+	}
+
+	r := &Router{
+		m:        m,
+		typ:      typ,
+		settings: s,
+	}
+
+	if err := r.Resize(s.size); err != nil {
+		_ = r.removeWorkers(r.Workers(), ErrRouterWorkerRemoved)
+		return nil, err
+	}
+
+	if err := m.AddActor(r, append([]Option{TickInterval(s.resizeInterval)}, s.routerOpts...)...); err != nil {
+		_ = r.removeWorkers(r.Workers(), ErrRouterWorkerRemoved)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Workers returns the router's current workers
+func (r *Router) Workers() []Actor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workers := make([]Actor, len(r.workers))
+	for i, w := range r.workers {
+		workers[i] = w.a
+	}
+	return workers
+}
+
+// Resize spawns or removes workers until the router has n of them
+// removed workers end play with ErrRouterWorkerRemoved, and the messages still waiting in their mailboxes become
+// dead letters (see: Manager.AddDeadLetterObserver())
+func (r *Router) Resize(n int) error {
+	if n < 1 {
+		return errors.Wrapf(ErrInvalidRouterSize, "%d", n)
+	}
+
+	r.resizeMu.Lock()
+	defer r.resizeMu.Unlock()
+
+	r.mu.Lock()
+	have := len(r.workers)
+	var removed []Actor
+	if n < have {
+		for _, w := range r.workers[n:] {
+			removed = append(removed, w.a)
+		}
+		r.workers = r.workers[:n]
+		r.rebuildRingLocked()
+	}
+	r.mu.Unlock()
+
+	if len(removed) > 0 {
+		return r.removeWorkers(removed, ErrRouterWorkerRemoved)
+	}
+
+	for i := have; i < n; i++ {
+		if err := r.addWorker(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Router) addWorker() error {
+	a, err := SpawnActor(r.typ, r.settings.workerSpawn...)
+	if err != nil {
+		return err
+	}
+
+	if err := r.m.AddActor(a, r.settings.workerOpts...); err != nil {
+		_ = DestroyActor(a)
+		return err
+	}
+
+	r.mu.Lock()
+	r.nextID++
+	r.workers = append(r.workers, routerWorker{
+		a:  a,
+		id: r.nextID,
+	})
+	r.rebuildRingLocked()
+	r.mu.Unlock()
+	return nil
+}
+
+// removeWorkers removes the workers from the manager, ignoring those that are already gone
+func (r *Router) removeWorkers(workers []Actor, reason error) error {
+	errs := r.m.removeActors(workers, reason)
+	for i, err := range errs {
+		if errors.Is(err, ErrActorNotFound) {
+			errs[i] = nil
+		}
+	}
+	return newBatchError(errs)
+}
+
+func (r *Router) rebuildRingLocked() {
+	if r.settings.strategy != RouteConsistentHashing {
+		return
+	}
+
+	r.ring = r.ring[:0]
+	for _, w := range r.workers {
+		for v := 0; v < routerVirtualNodes; v++ {
+			r.ring = append(r.ring, routerRingPoint{
+				hash:   routerHash(fmt.Sprintf("%d-%d", w.id, v)),
+				worker: w.a,
+			})
+		}
+	}
+	sort.Slice(r.ring, func(i, j int) bool {
+		return r.ring[i].hash < r.ring[j].hash
+	})
+}
+
+func routerHash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// route picks the worker(s) the message goes to
+func (r *Router) route(msg Message) ([]Actor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.workers) == 0 {
+		return nil, ErrActorNotFound
+	}
+
+	switch r.settings.strategy {
+	case RouteRandom:
+		return []Actor{r.workers[rand.Intn(len(r.workers))].a}, nil
+
+	case RouteSmallestMailbox:
+		best := r.workers[0].a
+		bestLen := -1
+		for _, w := range r.workers {
+			n, ok := r.m.mailboxLen(w.a)
+			if !ok {
+				continue
+			}
+			if bestLen < 0 || n < bestLen {
+				best, bestLen = w.a, n
+			}
+		}
+		return []Actor{best}, nil
+
+	case RouteConsistentHashing:
+		h, ok := msg.(ConsistentHashableIntf)
+		if !ok {
+			return nil, errors.Wrapf(ErrMessageNotHashable, "%T", msg)
+		}
+		key := routerHash(h.ConsistentHashKey())
+		i := sort.Search(len(r.ring), func(i int) bool {
+			return r.ring[i].hash >= key
+		})
+		if i == len(r.ring) {
+			i = 0
+		}
+		return []Actor{r.ring[i].worker}, nil
+
+	case RouteBroadcast:
+		workers := make([]Actor, len(r.workers))
+		for i, w := range r.workers {
+			workers[i] = w.a
+		}
+		return workers, nil
+
+	default:
+		w := r.workers[r.next%len(r.workers)]
+		r.next++
+		return []Actor{w.a}, nil
+	}
+}
+
+// Receive forwards the message to the worker(s) the router's strategy picks
+func (r *Router) Receive(msg Message) error {
+	workers, err := r.route(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range workers {
+		// failures are reported as dead letters
+		_ = r.m.Tell(w, msg)
+	}
+	return nil
+}
+
+// Respond answers the message via the Respond() of the worker the router's strategy picks - or the first worker, for
+// broadcast routers
+func (r *Router) Respond(msg Message) (Message, error) {
+	workers, err := r.route(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return Respond(workers[0], msg)
+}
+
+// WantTick reports if the router resizes itself
+func (r *Router) WantTick() (bool, error) {
+	return r.settings.resizable(), nil
+}
+
+// Tick grows or shrinks a resizable router based on how backed up its workers' mailboxes are
+func (r *Router) Tick(deltaTime time.Duration) error {
+	workers := r.Workers()
+	backlog := 0
+	for _, w := range workers {
+		if n, ok := r.m.mailboxLen(w); ok {
+			backlog += n
+		}
+	}
+
+	switch {
+	case len(workers) < r.settings.maxSize && backlog > r.settings.resizeBacklog*len(workers):
+		return r.Resize(len(workers) + 1)
+	case len(workers) > r.settings.minSize && backlog == 0:
+		return r.Resize(len(workers) - 1)
+	default:
+		return nil
+	}
+}
+
+// EndPlay removes the router's workers from its manager
+func (r *Router) EndPlay(endPlayReason error) error {
+	r.mu.Lock()
+	workers := make([]Actor, len(r.workers))
+	for i, w := range r.workers {
+		workers[i] = w.a
+	}
+	r.workers = nil
+	r.ring = nil
+	r.mu.Unlock()
+
+	return r.removeWorkers(workers, endPlayReason)
+}
+
+// mailboxLen returns the number of messages waiting in the actor's mailbox
+func (m *Manager) mailboxLen(a Actor) (int, bool) {
+	m.mu.RLock()
+	ami, found := m.actors[a]
+	m.mu.RUnlock()
+	if !found {
+		return 0, false
+	}
+	return ami.mailbox.len(), true
+}
@@ -0,0 +1,234 @@
+package actor_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type routerDeliveryTest struct {
+	worker actor.Actor
+	msg    actor.Message
+}
+
+// the workers are spawned by the router, so they report to (and wait on) these
+var (
+	routerDeliveriesTest chan routerDeliveryTest
+	routerHeldTest       chan struct{}
+	routerGateTest       chan struct{}
+)
+
+type routerHoldTest struct{}
+
+type routerKeyTest string
+
+func (k routerKeyTest) ConsistentHashKey() string {
+	return string(k)
+}
+
+// routerWorkerTest isn't zero-sized, so that each worker spawned is a distinct actor
+type routerWorkerTest struct {
+	_ byte
+}
+
+func (a *routerWorkerTest) Receive(msg actor.Message) error {
+	if _, ok := msg.(routerHoldTest); ok {
+		routerHeldTest <- struct{}{}
+		<-routerGateTest
+		return nil
+	}
+
+	routerDeliveriesTest <- routerDeliveryTest{
+		worker: a,
+		msg:    msg,
+	}
+	return nil
+}
+
+func (a *routerWorkerTest) Respond(msg actor.Message) (actor.Message, error) {
+	return a, nil
+}
+
+func startRouterTest(t *testing.T, opts ...actor.RouterOption) (*actor.Manager, *actor.Router) {
+	t.Helper()
+
+	routerDeliveriesTest = make(chan routerDeliveryTest, 64)
+	routerHeldTest = make(chan struct{})
+	routerGateTest = make(chan struct{})
+
+	m := startTestManager(t)
+
+	r, err := actor.NewRouter(m, reflect.TypeOf(routerWorkerTest{}), append([]actor.RouterOption{
+		actor.WorkerOptions(actor.TickInterval(time.Hour)),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, r
+}
+
+func expectRouterDeliveries(t *testing.T, n int) []routerDeliveryTest {
+	t.Helper()
+
+	var deliveries []routerDeliveryTest
+	for len(deliveries) < n {
+		select {
+		case d := <-routerDeliveriesTest:
+			deliveries = append(deliveries, d)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d deliveries, got %d", n, len(deliveries))
+		}
+	}
+	return deliveries
+}
+
+func TestRouterRoundRobin(t *testing.T) {
+	m, r := startRouterTest(t, actor.RouterSize(3))
+
+	for i := 0; i < 6; i++ {
+		if err := m.Tell(r, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts := make(map[actor.Actor]int)
+	for _, d := range expectRouterDeliveries(t, 6) {
+		counts[d.worker]++
+	}
+	for _, w := range r.Workers() {
+		if counts[w] != 2 {
+			t.Fatalf("expected each worker to get 2 messages, got %v", counts)
+		}
+	}
+}
+
+func TestRouterBroadcast(t *testing.T) {
+	m, r := startRouterTest(t, actor.RouterSize(3), actor.Routing(actor.RouteBroadcast))
+
+	if err := m.Tell(r, "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[actor.Actor]bool)
+	for _, d := range expectRouterDeliveries(t, 3) {
+		seen[d.worker] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected every worker to get the message, got %d", len(seen))
+	}
+}
+
+func TestRouterConsistentHashing(t *testing.T) {
+	m, r := startRouterTest(t, actor.RouterSize(4), actor.Routing(actor.RouteConsistentHashing))
+
+	keys := []routerKeyTest{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for round := 0; round < 2; round++ {
+		for _, k := range keys {
+			if err := m.Tell(r, k); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	owners := make(map[actor.Message]actor.Actor)
+	for _, d := range expectRouterDeliveries(t, 2*len(keys)) {
+		if owner, ok := owners[d.msg]; ok && owner != d.worker {
+			t.Fatalf("expected key %v to go to the same worker every time", d.msg)
+		}
+		owners[d.msg] = d.worker
+	}
+}
+
+func TestRouterStrategies(t *testing.T) {
+	for _, strategy := range []actor.RoutingStrategy{actor.RouteRandom, actor.RouteSmallestMailbox} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			m, r := startRouterTest(t, actor.RouterSize(3), actor.Routing(strategy))
+
+			for i := 0; i < 9; i++ {
+				if err := m.Tell(r, i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expectRouterDeliveries(t, 9)
+		})
+	}
+}
+
+func TestRouterAsk(t *testing.T) {
+	m, r := startRouterTest(t, actor.RouterSize(2))
+
+	reply, err := m.Ask(context.Background(), r, "who?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != r.Workers()[0] {
+		t.Fatalf("expected the first worker to reply, got %v", reply)
+	}
+}
+
+func TestRouterResize(t *testing.T) {
+	m, r := startRouterTest(t,
+		actor.ResizableRouter(1, 3),
+		actor.RouterResizeBacklog(1),
+		actor.RouterResizeInterval(time.Hour))
+
+	// hold the tick goroutine so that the worker's messages back up
+	w := r.Workers()[0]
+	if err := m.Tell(w, routerHoldTest{}); err != nil {
+		t.Fatal(err)
+	}
+	<-routerHeldTest
+	for i := 0; i < 4; i++ {
+		if err := m.Tell(w, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := r.Tick(0); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(r.Workers()); n != 2 {
+		t.Fatalf("expected the router to grow to 2 workers, got %d", n)
+	}
+
+	close(routerGateTest)
+	expectRouterDeliveries(t, 4)
+
+	if err := r.Tick(0); err != nil {
+		t.Fatal(err)
+	}
+	workers := r.Workers()
+	if len(workers) != 1 || workers[0] != w {
+		t.Fatalf("expected the router to shrink back to its first worker, got %d", len(workers))
+	}
+}
+
+func TestRouterRemoved(t *testing.T) {
+	m, r := startRouterTest(t, actor.RouterSize(3))
+
+	if err := m.RemoveActor(r, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(m.Stats().Actors); n != 0 {
+		t.Fatalf("expected the workers to be removed with the router, got %d actors", n)
+	}
+}
+
+func TestInvalidRouterOptions(t *testing.T) {
+	m := actor.NewManager()
+	typ := reflect.TypeOf(routerWorkerTest{})
+
+	if _, err := actor.NewRouter(m, typ, actor.RouterSize(0)); !errors.Is(err, actor.ErrInvalidRouterSize) {
+		t.Fatalf("expected ErrInvalidRouterSize, got %v", err)
+	}
+	if _, err := actor.NewRouter(m, typ, actor.ResizableRouter(3, 2)); !errors.Is(err, actor.ErrInvalidRouterSize) {
+		t.Fatalf("expected ErrInvalidRouterSize, got %v", err)
+	}
+	if _, err := actor.NewRouter(m, typ, actor.Routing(actor.RoutingStrategy(99))); !errors.Is(err, actor.ErrInvalidRoutingStrategy) {
+		t.Fatalf("expected ErrInvalidRoutingStrategy, got %v", err)
+	}
+}
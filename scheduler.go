@@ -0,0 +1,182 @@
+package actor
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// tickSchedule is a min-heap of the interval tick groups, ordered by when they are next due to tick
+// it is guarded by the manager's lock
+type tickSchedule []*actorList
+
+func (s tickSchedule) Len() int {
+	return len(s)
+}
+
+func (s tickSchedule) Less(i, j int) bool {
+	return s[i].nextDue.Before(s[j].nextDue)
+}
+
+func (s tickSchedule) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+	s[i].heapIndex = i
+	s[j].heapIndex = j
+}
+
+func (s *tickSchedule) Push(x interface{}) {
+	tg := x.(*actorList)
+	tg.heapIndex = len(*s)
+	*s = append(*s, tg)
+}
+
+func (s *tickSchedule) Pop() interface{} {
+	old := *s
+	n := len(old)
+	tg := old[n-1]
+	old[n-1] = nil
+	tg.heapIndex = -1
+	*s = old[:n-1]
+	return tg
+}
+
+// scheduleLocked adds a tick group to the schedule, first due at the next multiple of its interval (offset by its
+// phase) since the manager was created - or at the next time in its calendar, if it has one
+// m.mu must be held
+func (m *Manager) scheduleLocked(tg *actorList, now time.Time) {
+	if tg.calendar != nil {
+		next, ok := tg.calendar.next(now)
+		if !ok {
+			// nothing left in the calendar
+			return
+		}
+		tg.nextDue = next
+		heap.Push(&m.schedule, tg)
+		return
+	}
+
+	origin := m.epoch.Add(tg.phase)
+	elapsed := now.Sub(origin)
+	next := origin
+	if elapsed >= 0 {
+		next = origin.Add((elapsed/tg.interval + 1) * tg.interval)
+	}
+	tg.nextDue = next
+	heap.Push(&m.schedule, tg)
+}
+
+// unscheduleLocked removes a tick group from the schedule
+// m.mu must be held
+func (m *Manager) unscheduleLocked(tg *actorList) {
+	if tg.heapIndex >= 0 && tg.heapIndex < len(m.schedule) && m.schedule[tg.heapIndex] == tg {
+		heap.Remove(&m.schedule, tg.heapIndex)
+	}
+}
+
+// nextDue returns the manager's clock (and its generation) and when the next tick group is due to tick by it, if there are any
+func (m *Manager) nextDue() (Clock, uint64, time.Time, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.schedule) == 0 {
+		return m.clock, m.clockGen, time.Time{}, false
+	}
+	return m.clock, m.clockGen, m.schedule[0].nextDue, true
+}
+
+// popDue returns the tick groups that are due to tick as of now, in the order they became due, and reschedules them
+// like a time.Ticker, groups that have fallen more than an interval behind skip the ticks they missed
+// (calendar groups skip to the next time in their calendar, leaving the schedule once there are none left)
+func (m *Manager) popDue() []*actorList {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	var due []*actorList
+	for len(m.schedule) > 0 && !m.schedule[0].nextDue.After(now) {
+		tg := m.schedule[0]
+		due = append(due, tg)
+
+		if tg.calendar != nil {
+			if next, ok := tg.calendar.next(now); ok {
+				tg.nextDue = next
+				heap.Fix(&m.schedule, 0)
+			} else {
+				heap.Pop(&m.schedule)
+			}
+			continue
+		}
+
+		next := tg.nextDue.Add(tg.interval)
+		if !next.After(now) {
+			missed := now.Sub(tg.nextDue) / tg.interval
+			next = tg.nextDue.Add((missed + 1) * tg.interval)
+		}
+		tg.nextDue = next
+		heap.Fix(&m.schedule, 0)
+	}
+	return due
+}
+
+// resetTimer safely resets a timer that may or may not have fired (and may or may not have been drained)
+func resetTimer(t Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C():
+		default:
+		}
+	}
+	t.Reset(d)
+}
+
+func (m *Manager) processTickGroups(ctx context.Context) {
+	go func() {
+		defer m.Stop()
+
+		// the timer is made by the manager's clock, so it's replaced if the clock is (see: SetClock())
+		var timer Timer
+		var timerClockGen uint64
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+	mainTickLoop:
+		for {
+			var timerC <-chan time.Time
+			if clock, gen, due, ok := m.nextDue(); ok {
+				if timer == nil || gen != timerClockGen {
+					if timer != nil {
+						timer.Stop()
+					}
+					timer = clock.NewTimer(due.Sub(clock.Now()))
+					timerClockGen = gen
+				} else {
+					resetTimer(timer, due.Sub(clock.Now()))
+				}
+				timerC = timer.C()
+			}
+
+			select {
+			case <-ctx.Done():
+				// done!
+				break mainTickLoop
+			case <-m.tickGroupsUpdatedCh:
+				// updated! - the next due time is picked up at the top of the loop
+			case <-m.mailboxCh:
+				// messages!
+				m.processMailboxes()
+			case req := <-m.tickFrameCh:
+				// frame!
+				m.processFrame(ctx, req)
+			case <-timerC:
+				for _, tg := range m.popDue() {
+					m.tickActorList(ctx, tg)
+				}
+			}
+		}
+		// we're done, signal a stop
+		m.tickStoppedCh <- struct{}{}
+	}()
+}
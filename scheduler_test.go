@@ -0,0 +1,82 @@
+package actor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type schedulerActorTest struct {
+	id int
+}
+
+// BenchmarkTickLoopWakeup measures the cost of waking the manager's tick goroutine (via TickFrameSync) against
+// the number of distinct tick intervals it is waiting on
+func BenchmarkTickLoopWakeup(b *testing.B) {
+	for _, intervals := range []int{1, 10, 100, 1000} {
+		b.Run(fmt.Sprintf("intervals=%d", intervals), func(b *testing.B) {
+			m := startTestManager(b)
+
+			specs := make([]actor.ActorSpec, intervals)
+			for i := range specs {
+				specs[i] = actor.ActorSpec{
+					Actor:   &schedulerActorTest{id: i},
+					Options: []actor.Option{actor.TickInterval(time.Hour + time.Duration(i)*time.Millisecond)},
+				}
+			}
+			if err := m.AddActors(specs...); err != nil {
+				b.Fatal(err)
+			}
+
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := m.TickFrameSync(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+type scheduleOrderActorTest struct {
+	interval time.Duration
+	ticks    chan time.Duration
+}
+
+func (a *scheduleOrderActorTest) Tick(deltaTime time.Duration) error {
+	select {
+	case a.ticks <- a.interval:
+	default:
+	}
+	return nil
+}
+
+func TestTickScheduleOrder(t *testing.T) {
+	m := startTestManager(t)
+
+	ticks := make(chan time.Duration, 100)
+	for _, interval := range []time.Duration{40 * time.Millisecond, 10 * time.Millisecond} {
+		if err := m.AddActor(&scheduleOrderActorTest{interval: interval, ticks: ticks}, actor.TickInterval(interval)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	counts := make(map[time.Duration]int)
+	timeout := time.After(5 * time.Second)
+	for counts[40*time.Millisecond] < 2 {
+		select {
+		case interval := <-ticks:
+			counts[interval]++
+		case <-timeout:
+			t.Fatalf("tick groups did not tick - got %v", counts)
+		}
+	}
+
+	if counts[10*time.Millisecond] < 4 {
+		t.Fatalf("expected the faster group to tick more often, got %v", counts)
+	}
+}
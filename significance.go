@@ -0,0 +1,184 @@
+package actor
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSignificanceBucketsRequired is for when a SignificancePolicy is set without any buckets to move actors between
+var ErrSignificanceBucketsRequired = errors.New("significance buckets required")
+
+// DefaultSignificanceInterval is the default interval between evaluations of actor significance
+const DefaultSignificanceInterval = time.Second
+
+// SignificanceBucket is a tick rate that actors are moved into once their significance reaches MinSignificance
+type SignificanceBucket struct {
+	// MinSignificance is the lowest significance an actor may have to be placed in the bucket
+	MinSignificance float64
+	// TickInterval is the tick interval of the bucket (zero being the Every-Frame group)
+	TickInterval time.Duration
+}
+
+// SignificancePolicy describes how a manager moves actors that implement SignificanceIntf between tick rates
+type SignificancePolicy struct {
+	// Buckets are the tick rates actors are moved between
+	// actors with a significance lower than every bucket's MinSignificance are placed in the least significant bucket
+	Buckets []SignificanceBucket
+	// EvaluationInterval is how often the actors' significance is evaluated - DefaultSignificanceInterval if zero
+	EvaluationInterval time.Duration
+}
+
+// bucketFor returns the tick interval of the most significant bucket that the significance provided qualifies for
+// the buckets must be sorted from most to least significant
+func (p SignificancePolicy) bucketFor(significance float64) time.Duration {
+	for _, b := range p.Buckets {
+		if significance >= b.MinSignificance {
+			return b.TickInterval
+		}
+	}
+	return p.Buckets[len(p.Buckets)-1].TickInterval
+}
+
+type significanceState struct {
+	policy SignificancePolicy
+	timer  Timer
+	stopCh chan struct{}
+}
+
+// run posts an evaluation to the manager's tick goroutine each time the timer fires (see: evaluateSignificance())
+func (ss *significanceState) run(m *Manager) {
+	for {
+		select {
+		case <-ss.timer.C():
+			m.post(func() {
+				m.evaluateSignificance(ss)
+			})
+		case <-ss.stopCh:
+			return
+		}
+	}
+}
+
+func (ss *significanceState) stop() {
+	ss.timer.Stop()
+	close(ss.stopCh)
+}
+
+// SetSignificancePolicy sets the policy the manager uses to move actors that implement SignificanceIntf between tick rates
+// a policy without buckets is not allowed - use ClearSignificancePolicy() instead
+func (m *Manager) SetSignificancePolicy(p SignificancePolicy) error {
+	if len(p.Buckets) == 0 {
+		return ErrSignificanceBucketsRequired
+	}
+
+	p.Buckets = append([]SignificanceBucket(nil), p.Buckets...)
+	sort.SliceStable(p.Buckets, func(i, j int) bool {
+		return p.Buckets[i].MinSignificance > p.Buckets[j].MinSignificance
+	})
+
+	if p.EvaluationInterval <= 0 {
+		p.EvaluationInterval = DefaultSignificanceInterval
+	}
+
+	m.ClearSignificancePolicy()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.startSignificanceLocked(p)
+	return nil
+}
+
+// startSignificanceLocked schedules the policy's evaluations on the manager's clock
+func (m *Manager) startSignificanceLocked(p SignificancePolicy) {
+	ss := &significanceState{
+		policy: p,
+		timer:  m.clock.NewTimer(p.EvaluationInterval),
+		stopCh: make(chan struct{}),
+	}
+	go ss.run(m)
+	m.significance = ss
+}
+
+// ClearSignificancePolicy stops the manager from moving actors between tick rates
+// actors stay in the tick group they were last moved to
+func (m *Manager) ClearSignificancePolicy() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.significance != nil {
+		m.significance.stop()
+		m.significance = nil
+	}
+}
+
+// evaluateSignificance runs on the manager's tick goroutine
+func (m *Manager) evaluateSignificance(ss *significanceState) {
+	m.mu.RLock()
+	if m.significance != ss {
+		// replaced or cleared since the evaluation was scheduled
+		m.mu.RUnlock()
+		return
+	}
+	var actors []Actor
+	for a := range m.actors {
+		if _, ok := a.(SignificanceIntf); ok {
+			actors = append(actors, a)
+		}
+	}
+	log := m.loggerLocked()
+	m.mu.RUnlock()
+
+	for _, a := range actors {
+		significance, err := Significance(a)
+		if err != nil {
+			m.mu.RLock()
+			ami := m.actors[a]
+			m.mu.RUnlock()
+			log.Error("actor Significance failed", append(actorLogAttrs(a, ami), slog.Any("error", err))...)
+			continue
+		}
+
+		m.setTickInterval(a, ss.policy.bucketFor(significance))
+	}
+
+	m.mu.Lock()
+	if m.significance == ss && !m.stopping.Load() {
+		ss.timer.Reset(ss.policy.EvaluationInterval)
+	}
+	m.mu.Unlock()
+}
+
+// setTickInterval moves an actor to the tick group of the interval provided
+func (m *Manager) setTickInterval(a Actor, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ami, found := m.actors[a]
+	if !found || ami.settings.tickInterval == interval || ami.settings.calendar != nil {
+		// actors on a wall-clock schedule stay on it
+		return
+	}
+
+	from := ami.settings.tickInterval
+	loading := ami.lifecycle.load() == StateLoading
+	m.leaveTickGroupLocked(a, ami.settings.tickGroupKey())
+	ami.settings.tickInterval = interval
+	if interval != 0 {
+		ami.settings.tickPhase %= interval
+	} else {
+		ami.settings.tickPhase = 0
+	}
+	if !loading {
+		// loading actors join their tick group once they're ready
+		m.joinTickGroupLocked(a, ami.settings)
+	}
+	m.actors[a] = ami
+
+	m.loggerLocked().Debug("actor tick interval changed", append(actorLogAttrs(a, ami),
+		slog.Duration("from", from),
+		slog.Duration("to", interval))...)
+}
@@ -0,0 +1,116 @@
+package actor_test
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type significanceActorTest struct {
+	significance uint64
+	ticks        int32
+}
+
+func (a *significanceActorTest) Significance() (float64, error) {
+	return math.Float64frombits(atomic.LoadUint64(&a.significance)), nil
+}
+
+func (a *significanceActorTest) setSignificance(s float64) {
+	atomic.StoreUint64(&a.significance, math.Float64bits(s))
+}
+
+func (a *significanceActorTest) Tick(deltaTime time.Duration) error {
+	atomic.AddInt32(&a.ticks, 1)
+	return nil
+}
+
+func waitForActorInterval(t *testing.T, m *actor.Manager, interval time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats := m.Stats()
+		if len(stats.Actors) == 1 && stats.Actors[0].Interval == interval {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("actor not moved to %v - got %+v", interval, stats)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSignificancePolicy(t *testing.T) {
+	m := startTestManager(t)
+
+	if err := m.SetSignificancePolicy(actor.SignificancePolicy{}); err != actor.ErrSignificanceBucketsRequired {
+		t.Fatalf("expected ErrSignificanceBucketsRequired, got %v", err)
+	}
+
+	if err := m.SetSignificancePolicy(actor.SignificancePolicy{
+		Buckets: []actor.SignificanceBucket{
+			{MinSignificance: 0, TickInterval: time.Hour},
+			{MinSignificance: 1, TickInterval: time.Millisecond},
+		},
+		EvaluationInterval: time.Millisecond,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &significanceActorTest{}
+	a.setSignificance(2)
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForActorInterval(t, m, time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&a.ticks) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("significant actor did not tick")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	a.setSignificance(0.5)
+	waitForActorInterval(t, m, time.Hour)
+
+	m.ClearSignificancePolicy()
+	a.setSignificance(2)
+	time.Sleep(10 * time.Millisecond)
+	if stats := m.Stats(); stats.Actors[0].Interval != time.Hour {
+		t.Fatalf("actor moved after policy was cleared - got %+v", stats.Actors[0])
+	}
+}
+
+func TestSignificancePolicyFollowsClock(t *testing.T) {
+	m, clock := startClockTestManager(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if err := m.SetSignificancePolicy(actor.SignificancePolicy{
+		Buckets: []actor.SignificanceBucket{
+			{MinSignificance: 0, TickInterval: time.Hour},
+			{MinSignificance: 1, TickInterval: time.Millisecond},
+		},
+		EvaluationInterval: time.Minute,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &significanceActorTest{}
+	a.setSignificance(2)
+	if err := m.AddActor(a, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if stats := m.Stats(); stats.Actors[0].Interval != time.Hour {
+		t.Fatalf("actor moved before the clock reached the evaluation - got %+v", stats.Actors[0])
+	}
+
+	clock.Advance(time.Minute)
+	waitForActorInterval(t, m, time.Millisecond)
+}
@@ -0,0 +1,427 @@
+package actor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidSnapshot is for when a binary snapshot could not be decoded
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+)
+
+// SnapshotMarshalerIntf is for actors that want to control how their state is saved into a snapshot
+// otherwise, the exported fields of the actor are saved
+type SnapshotMarshalerIntf interface {
+	MarshalSnapshot() ([]byte, error)
+}
+
+// SnapshotUnmarshalerIntf is for actors that want to control how their state is loaded from a snapshot
+// otherwise, the exported fields of the actor are loaded
+type SnapshotUnmarshalerIntf interface {
+	UnmarshalSnapshot(data []byte) error
+}
+
+// ActorSnapshot is the saved state of a single actor
+type ActorSnapshot struct {
+	Class        string        `json:"class"`
+	TickInterval time.Duration `json:"tickInterval"`
+	TickPhase    time.Duration `json:"tickPhase,omitempty"`
+	TickSchedule string        `json:"tickSchedule,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+
+	AccumulateSkippedTime bool `json:"accumulateSkippedTime,omitempty"`
+
+	// LoadTimeout is nil for actors using DefaultLoadTimeout
+	LoadTimeout *time.Duration    `json:"loadTimeout,omitempty"`
+	LoadFailure LoadFailurePolicy `json:"loadFailure,omitempty"`
+
+	// MailboxCapacity is zero for actors with an unbounded mailbox
+	MailboxCapacity   int            `json:"mailboxCapacity,omitempty"`
+	MailboxOverflow   OverflowPolicy `json:"mailboxOverflow,omitempty"`
+	PriorityMailbox   bool           `json:"priorityMailbox,omitempty"`
+	MailboxThroughput int            `json:"mailboxThroughput,omitempty"`
+
+	State []byte `json:"state"`
+}
+
+// Snapshot is the saved state of the actors in a manager
+// it may be encoded as JSON (via encoding/json) or in a compact binary format (via MarshalBinary)
+type Snapshot struct {
+	Actors []ActorSnapshot `json:"actors"`
+}
+
+func newActorSnapshot(class string, s actorSettings, state []byte) ActorSnapshot {
+	as := ActorSnapshot{
+		Class:                 class,
+		TickInterval:          s.tickInterval,
+		TickPhase:             s.tickPhase,
+		Name:                  s.name,
+		Tags:                  s.tags,
+		AccumulateSkippedTime: s.accumulateSkipped,
+		LoadFailure:           s.loadFailure,
+		MailboxCapacity:       s.mailbox.capacity,
+		MailboxOverflow:       s.mailbox.overflow,
+		PriorityMailbox:       s.mailbox.priority,
+		MailboxThroughput:     s.mailbox.throughput,
+		State:                 state,
+	}
+	if s.calendar != nil {
+		as.TickSchedule = s.calendar.String()
+	}
+	if s.loadTimeout != DefaultLoadTimeout {
+		timeout := s.loadTimeout
+		as.LoadTimeout = &timeout
+	}
+	return as
+}
+
+func (as ActorSnapshot) options() ([]Option, error) {
+	s := actorSettings{
+		tickInterval:      as.TickInterval,
+		tickPhase:         as.TickPhase,
+		name:              as.Name,
+		tags:              as.Tags,
+		accumulateSkipped: as.AccumulateSkippedTime,
+		loadTimeout:       DefaultLoadTimeout,
+		loadFailure:       as.LoadFailure,
+		mailbox: mailboxSettings{
+			capacity:   as.MailboxCapacity,
+			overflow:   as.MailboxOverflow,
+			priority:   as.PriorityMailbox,
+			throughput: as.MailboxThroughput,
+		},
+	}
+	if as.TickSchedule != "" {
+		c, err := parseTickSchedule(as.TickSchedule)
+		if err != nil {
+			return nil, err
+		}
+		s.calendar = c
+	}
+	if as.LoadTimeout != nil {
+		s.loadTimeout = *as.LoadTimeout
+	}
+	return s.options(), nil
+}
+
+func marshalActorState(a Actor) ([]byte, error) {
+	if t, ok := a.(SnapshotMarshalerIntf); ok {
+		return t.MarshalSnapshot()
+	}
+
+	return json.Marshal(a)
+}
+
+func unmarshalActorState(a Actor, data []byte) error {
+	if t, ok := a.(SnapshotUnmarshalerIntf); ok {
+		return t.UnmarshalSnapshot(data)
+	}
+
+	return json.Unmarshal(data, a)
+}
+
+// Snapshot saves the state of every actor in the manager, in the order they were added
+// NOTE: every actor must be of a class registered via RegisterClass() and should not be ticking while this runs
+func (m *Manager) Snapshot() (*Snapshot, error) {
+	type entry struct {
+		a   Actor
+		ami actorMgrInfo
+	}
+
+	m.mu.RLock()
+	entries := make([]entry, 0, len(m.actors))
+	for a, ami := range m.actors {
+		entries = append(entries, entry{a: a, ami: ami})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ami.id < entries[j].ami.id
+	})
+
+	s := Snapshot{
+		Actors: make([]ActorSnapshot, 0, len(entries)),
+	}
+	for _, e := range entries {
+		class, err := ClassName(e.a)
+		if err != nil {
+			return nil, err
+		}
+
+		state, err := marshalActorState(e.a)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Actors = append(s.Actors, newActorSnapshot(class, e.ami.settings, state))
+	}
+
+	return &s, nil
+}
+
+// Restore re-creates the actors in the snapshot and adds them to the manager
+// each actor is spawned via SpawnActor() with DeferredSpawnActor() enabled, has its state applied,
+// then is finished via FinishSpawningActor() and added with its original Options
+func (m *Manager) Restore(s *Snapshot) ([]Actor, error) {
+	opts := []SpawnActorOption{
+		DeferredSpawnActor(),
+	}
+
+	actors := make([]Actor, 0, len(s.Actors))
+	for _, as := range s.Actors {
+		typ, err := ClassByName(as.Class)
+		if err != nil {
+			return actors, err
+		}
+
+		a, err := SpawnActor(typ, opts...)
+		if err != nil {
+			return actors, err
+		}
+
+		if err := unmarshalActorState(a, as.State); err != nil {
+			return actors, err
+		}
+
+		if err := FinishSpawningActor(a, opts...); err != nil {
+			return actors, err
+		}
+
+		addOpts, err := as.options()
+		if err != nil {
+			return actors, err
+		}
+
+		if err := m.AddActor(a, addOpts...); err != nil {
+			return actors, err
+		}
+
+		actors = append(actors, a)
+	}
+
+	return actors, nil
+}
+
+// snapshotMagic is followed by the format version - every version may still be read:
+//   - version 1 lacks actor names
+//   - version 2 lacks tick phases
+//   - version 3 lacks tick schedules
+//   - version 4 lacks the load and mailbox settings
+var snapshotMagic = []byte("ACTS")
+
+const (
+	snapshotVersion   = 5
+	snapshotVersionV4 = 4
+	snapshotVersionV3 = 3
+	snapshotVersionV2 = 2
+	snapshotVersionV1 = 1
+)
+
+// flags for the boolean settings of an actor in a binary snapshot
+const (
+	snapshotFlagAccumulateSkipped = 1 << iota
+	snapshotFlagPriorityMailbox
+	snapshotFlagLoadTimeout
+)
+
+// MarshalBinary encodes the snapshot into a compact binary format
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(snapshotMagic)
+	buf.WriteByte(snapshotVersion)
+
+	writeUvarint(&buf, uint64(len(s.Actors)))
+	for _, as := range s.Actors {
+		writeBytes(&buf, []byte(as.Class))
+		writeVarint(&buf, int64(as.TickInterval))
+		writeVarint(&buf, int64(as.TickPhase))
+		writeBytes(&buf, []byte(as.TickSchedule))
+		writeBytes(&buf, []byte(as.Name))
+		writeUvarint(&buf, uint64(len(as.Tags)))
+		for _, tag := range as.Tags {
+			writeBytes(&buf, []byte(tag))
+		}
+
+		var flags uint64
+		if as.AccumulateSkippedTime {
+			flags |= snapshotFlagAccumulateSkipped
+		}
+		if as.PriorityMailbox {
+			flags |= snapshotFlagPriorityMailbox
+		}
+		if as.LoadTimeout != nil {
+			flags |= snapshotFlagLoadTimeout
+		}
+		writeUvarint(&buf, flags)
+		if as.LoadTimeout != nil {
+			writeVarint(&buf, int64(*as.LoadTimeout))
+		}
+		writeVarint(&buf, int64(as.LoadFailure))
+		writeVarint(&buf, int64(as.MailboxCapacity))
+		writeVarint(&buf, int64(as.MailboxOverflow))
+		writeVarint(&buf, int64(as.MailboxThroughput))
+
+		writeBytes(&buf, as.State)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the snapshot from the compact binary format produced by MarshalBinary
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, snapshotMagic) || len(data) <= len(snapshotMagic) {
+		return errors.Wrap(ErrInvalidSnapshot, "bad header")
+	}
+
+	version := data[len(snapshotMagic)]
+	if version < snapshotVersionV1 || version > snapshotVersion {
+		return errors.Wrapf(ErrInvalidSnapshot, "unsupported version %d", version)
+	}
+
+	r := bytes.NewReader(data[len(snapshotMagic)+1:])
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSnapshot, err.Error())
+	}
+
+	actors := make([]ActorSnapshot, 0)
+	for i := uint64(0); i < count; i++ {
+		var as ActorSnapshot
+
+		class, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		as.Class = string(class)
+
+		intv, err := binary.ReadVarint(r)
+		if err != nil {
+			return errors.Wrap(ErrInvalidSnapshot, err.Error())
+		}
+		as.TickInterval = time.Duration(intv)
+
+		if version >= snapshotVersionV3 {
+			phase, err := binary.ReadVarint(r)
+			if err != nil {
+				return errors.Wrap(ErrInvalidSnapshot, err.Error())
+			}
+			as.TickPhase = time.Duration(phase)
+		}
+
+		if version >= snapshotVersionV4 {
+			schedule, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			as.TickSchedule = string(schedule)
+		}
+
+		if version >= snapshotVersionV2 {
+			name, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			as.Name = string(name)
+		}
+
+		numTags, err := binary.ReadUvarint(r)
+		if err != nil {
+			return errors.Wrap(ErrInvalidSnapshot, err.Error())
+		}
+		for t := uint64(0); t < numTags; t++ {
+			tag, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			as.Tags = append(as.Tags, string(tag))
+		}
+
+		if version >= snapshotVersion {
+			if err := readActorSettings(r, &as); err != nil {
+				return err
+			}
+		}
+
+		if as.State, err = readBytes(r); err != nil {
+			return err
+		}
+
+		actors = append(actors, as)
+	}
+
+	s.Actors = actors
+	return nil
+}
+
+// readActorSettings reads the load and mailbox settings of a version 5 snapshot
+func readActorSettings(r *bytes.Reader, as *ActorSnapshot) error {
+	flags, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSnapshot, err.Error())
+	}
+	as.AccumulateSkippedTime = flags&snapshotFlagAccumulateSkipped != 0
+	as.PriorityMailbox = flags&snapshotFlagPriorityMailbox != 0
+
+	if flags&snapshotFlagLoadTimeout != 0 {
+		timeout, err := binary.ReadVarint(r)
+		if err != nil {
+			return errors.Wrap(ErrInvalidSnapshot, err.Error())
+		}
+		as.LoadTimeout = new(time.Duration)
+		*as.LoadTimeout = time.Duration(timeout)
+	}
+
+	var values [4]int64
+	for i := range values {
+		if values[i], err = binary.ReadVarint(r); err != nil {
+			return errors.Wrap(ErrInvalidSnapshot, err.Error())
+		}
+	}
+	as.LoadFailure = LoadFailurePolicy(values[0])
+	as.MailboxCapacity = int(values[1])
+	as.MailboxOverflow = OverflowPolicy(values[2])
+	as.MailboxThroughput = int(values[3])
+	return nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	buf.Write(b[:n])
+}
+
+func writeVarint(buf *bytes.Buffer, v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	buf.Write(b[:n])
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	writeUvarint(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSnapshot, err.Error())
+	}
+	if l > uint64(r.Len()) {
+		return nil, errors.Wrap(ErrInvalidSnapshot, "truncated data")
+	}
+
+	data := make([]byte, l)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrap(ErrInvalidSnapshot, err.Error())
+	}
+	return data, nil
+}
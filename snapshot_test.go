@@ -0,0 +1,289 @@
+package actor_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type snapshotActorTest struct {
+	Health int
+	Name   string
+
+	constructedHealth int
+}
+
+func (a *snapshotActorTest) OnConstruction() error {
+	a.constructedHealth = a.Health
+	return nil
+}
+
+func init() {
+	if err := actor.RegisterClass("snapshotActorTest", reflect.TypeOf(snapshotActorTest{})); err != nil {
+		panic(err)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	src := startTestManager(t)
+
+	act, err := actor.SpawnActor(reflect.TypeOf(snapshotActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := act.(*snapshotActorTest)
+	a.Health = 42
+	a.Name = "bob"
+
+	if err := src.AddActor(a, actor.TickInterval(time.Hour), actor.Tags("player", "hero")); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := src.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := snap.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fromBin, fromJSON actor.Snapshot
+	if err := fromBin.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(js, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, s := range map[string]*actor.Snapshot{"binary": &fromBin, "json": &fromJSON} {
+		if !reflect.DeepEqual(s, snap) {
+			t.Fatalf("%s: snapshot did not round-trip - expected %+v, got %+v", name, snap, s)
+		}
+
+		dst := startTestManager(t)
+		restored, err := dst.Restore(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(restored) != 1 {
+			t.Fatalf("%s: expected 1 restored actor, got %d", name, len(restored))
+		}
+
+		r, ok := restored[0].(*snapshotActorTest)
+		if !ok {
+			t.Fatalf("%s: expected snapshotActorTest, got %v", name, reflect.TypeOf(restored[0]))
+		}
+
+		if r.Health != 42 || r.Name != "bob" {
+			t.Fatalf("%s: state not restored - got %+v", name, r)
+		}
+
+		if r.constructedHealth != 42 {
+			t.Fatalf("%s: state not applied before construction - expected 42, got %d", name, r.constructedHealth)
+		}
+
+		resnap, err := dst.Snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(resnap, snap) {
+			t.Fatalf("%s: restored settings differ - expected %+v, got %+v", name, snap, resnap)
+		}
+	}
+}
+
+func TestSnapshotUnregisteredClass(t *testing.T) {
+	m := startTestManager(t)
+
+	if err := m.AddActor(&spawnActorTest{}, actor.TickInterval(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Snapshot(); err == nil {
+		t.Fatal("expected an error for an unregistered class")
+	}
+}
+
+func TestSnapshotActorSettings(t *testing.T) {
+	for name, opt := range map[string]actor.Option{
+		"BoundedMailbox":        actor.BoundedMailbox(8, actor.OverflowDropOldest),
+		"PriorityMailbox":       actor.PriorityMailbox(),
+		"MailboxThroughput":     actor.MailboxThroughput(4),
+		"AccumulateSkippedTime": actor.AccumulateSkippedTime(),
+		"LoadTimeout":           actor.LoadTimeout(0), // zero is not the default, so it must be kept
+		"OnLoadFailure":         actor.OnLoadFailure(actor.LoadFailurePlay),
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := startTestManager(t)
+
+			for _, opts := range [][]actor.Option{
+				{actor.TickInterval(time.Hour)},
+				{actor.TickInterval(time.Hour), opt},
+			} {
+				a, err := actor.SpawnActor(reflect.TypeOf(snapshotActorTest{}))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := src.AddActor(a, opts...); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			snap, err := src.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reflect.DeepEqual(snap.Actors[0], snap.Actors[1]) {
+				t.Fatalf("expected the option to be saved, got %+v", snap.Actors[1])
+			}
+
+			bin, err := snap.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			js, err := json.Marshal(snap)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fromBin, fromJSON actor.Snapshot
+			if err := fromBin.UnmarshalBinary(bin); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(js, &fromJSON); err != nil {
+				t.Fatal(err)
+			}
+
+			for format, s := range map[string]*actor.Snapshot{"binary": &fromBin, "json": &fromJSON} {
+				dst := startTestManager(t)
+				if _, err := dst.Restore(s); err != nil {
+					t.Fatal(err)
+				}
+
+				resnap, err := dst.Snapshot()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(resnap, snap) {
+					t.Fatalf("%s: restored settings differ - expected %+v, got %+v", format, snap.Actors[1], resnap.Actors[1])
+				}
+			}
+		})
+	}
+}
+
+// snapshotWriterTest hand-encodes binary snapshots, so that every older version of the format can be read back
+type snapshotWriterTest struct {
+	bytes.Buffer
+}
+
+func (w *snapshotWriterTest) uvarint(v uint64) {
+	w.Write(binary.AppendUvarint(nil, v))
+}
+
+func (w *snapshotWriterTest) varint(v int64) {
+	w.Write(binary.AppendVarint(nil, v))
+}
+
+func (w *snapshotWriterTest) bytes(data string) {
+	w.uvarint(uint64(len(data)))
+	w.WriteString(data)
+}
+
+func TestSnapshotBinaryVersions(t *testing.T) {
+	state := `{"Health":7,"Name":"old"}`
+	loadTimeout := 5 * time.Second
+
+	for version, expected := range map[byte]actor.ActorSnapshot{
+		1: {Class: "snapshotActorTest", TickInterval: time.Hour, Tags: []string{"npc"}},
+		2: {Class: "snapshotActorTest", TickInterval: time.Hour, Name: "bob", Tags: []string{"npc"}},
+		3: {Class: "snapshotActorTest", TickInterval: time.Hour, TickPhase: time.Minute, Name: "bob", Tags: []string{"npc"}},
+		4: {Class: "snapshotActorTest", TickSchedule: "CRON_TZ=UTC 0 */5 * * * *", Name: "bob", Tags: []string{"npc"}},
+		5: {
+			Class:                 "snapshotActorTest",
+			TickInterval:          time.Hour,
+			TickPhase:             time.Minute,
+			Name:                  "bob",
+			Tags:                  []string{"npc"},
+			AccumulateSkippedTime: true,
+			LoadTimeout:           &loadTimeout,
+			LoadFailure:           actor.LoadFailurePlay,
+			MailboxCapacity:       8,
+			MailboxOverflow:       actor.OverflowDropOldest,
+			PriorityMailbox:       true,
+			MailboxThroughput:     4,
+		},
+	} {
+		t.Run(fmt.Sprint("V", version), func(t *testing.T) {
+			expected.State = []byte(state)
+
+			var w snapshotWriterTest
+			w.WriteString("ACTS")
+			w.WriteByte(version)
+			w.uvarint(1)
+			w.bytes(expected.Class)
+			w.varint(int64(expected.TickInterval))
+			if version >= 3 {
+				w.varint(int64(expected.TickPhase))
+			}
+			if version >= 4 {
+				w.bytes(expected.TickSchedule)
+			}
+			if version >= 2 {
+				w.bytes(expected.Name)
+			}
+			w.uvarint(uint64(len(expected.Tags)))
+			for _, tag := range expected.Tags {
+				w.bytes(tag)
+			}
+			if version >= 5 {
+				w.uvarint(7) // every flag
+				w.varint(int64(loadTimeout))
+				w.varint(int64(expected.LoadFailure))
+				w.varint(int64(expected.MailboxCapacity))
+				w.varint(int64(expected.MailboxOverflow))
+				w.varint(int64(expected.MailboxThroughput))
+			}
+			w.bytes(state)
+
+			var s actor.Snapshot
+			if err := s.UnmarshalBinary(w.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if len(s.Actors) != 1 || !reflect.DeepEqual(s.Actors[0], expected) {
+				t.Fatalf("expected %+v, got %+v", expected, s.Actors)
+			}
+
+			m := startTestManager(t)
+			restored, err := m.Restore(&s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := restored[0].(*snapshotActorTest); r.Health != 7 {
+				t.Fatalf("state not restored - got %+v", r)
+			}
+		})
+	}
+
+	var s actor.Snapshot
+	for _, version := range []byte{0, 6} {
+		if err := s.UnmarshalBinary([]byte{'A', 'C', 'T', 'S', version, 0}); !errors.Is(err, actor.ErrInvalidSnapshot) {
+			t.Fatalf("expected ErrInvalidSnapshot for version %d, got %v", version, err)
+		}
+	}
+}
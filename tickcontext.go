@@ -0,0 +1,56 @@
+package actor
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTimeDilation is for when a negative (or non-numeric) time dilation is provided
+var ErrInvalidTimeDilation = errors.New("invalid time dilation")
+
+// TickContext describes a single tick of an actor (see: TickWithContextIntf)
+type TickContext struct {
+	// Frame is the number of the frame being ticked for Every-Frame actors, or the number of frames finished so far
+	// for interval actors (see: Manager.Frame())
+	Frame uint64
+	// RealDeltaTime is the wall-clock time since the actor last ticked (or was added, if it hasn't yet)
+	RealDeltaTime time.Duration
+	// DeltaTime is RealDeltaTime scaled by the manager's time dilation - it's what Tick() receives
+	DeltaTime time.Duration
+	// Interval and Phase identify the tick group the actor was ticked from
+	Interval time.Duration
+	Phase    time.Duration
+}
+
+// actorTickState is the per-actor state of the tick loop - it's only touched from the manager's tick goroutine once
+// the actor has been added
+type actorTickState struct {
+	lastTick time.Time
+}
+
+// SetTimeDilation scales the deltaTime passed to the manager's actors - e.g.: 0.5 for half speed, 2 for double speed
+// and 0 to pause them (they still tick, but with a deltaTime of zero)
+// the real time elapsed remains available via TickContext.RealDeltaTime
+func (m *Manager) SetTimeDilation(scale float64) error {
+	if scale < 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return errors.Wrapf(ErrInvalidTimeDilation, "%v", scale)
+	}
+
+	m.timeDilation.Store(math.Float64bits(scale))
+	return nil
+}
+
+// TimeDilation returns the scale applied to the deltaTime passed to the manager's actors (see: SetTimeDilation())
+func (m *Manager) TimeDilation() float64 {
+	return math.Float64frombits(m.timeDilation.Load())
+}
+
+// dilate scales a real deltaTime by the time dilation provided
+func dilate(realDeltaTime time.Duration, scale float64) time.Duration {
+	if scale == 1 {
+		return realDeltaTime
+	}
+	return time.Duration(float64(realDeltaTime) * scale)
+}
@@ -0,0 +1,154 @@
+package actor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+	"github.com/pkg/errors"
+)
+
+type tickContextActorTest struct {
+	want atomic.Bool
+	last actor.TickContext
+	n    int
+}
+
+func newTickContextActorTest() *tickContextActorTest {
+	a := &tickContextActorTest{}
+	a.want.Store(true)
+	return a
+}
+
+func (a *tickContextActorTest) WantTick() (bool, error) {
+	return a.want.Load(), nil
+}
+
+func (a *tickContextActorTest) TickWithContext(tc actor.TickContext) error {
+	a.last = tc
+	a.n++
+	return nil
+}
+
+func (a *tickContextActorTest) Tick(deltaTime time.Duration) error {
+	panic("Tick should not be called when TickWithContext is implemented")
+}
+
+func TestTickContext(t *testing.T) {
+	m := startTestManager(t)
+	ctx := context.Background()
+
+	early := newTickContextActorTest()
+	if err := m.AddActor(early, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	late := newTickContextActorTest()
+	if err := m.AddActor(late, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if early.last.Frame != 1 || late.last.Frame != 1 {
+		t.Fatalf("expected frame 1, got %d and %d", early.last.Frame, late.last.Frame)
+	}
+	if early.last.Interval != 0 || early.last.Phase != 0 {
+		t.Fatalf("expected the Every-Frame group, got %+v", early.last)
+	}
+	if early.last.RealDeltaTime < 60*time.Millisecond {
+		t.Fatalf("expected the early actor's deltaTime to cover the time since it was added, got %v", early.last.RealDeltaTime)
+	}
+	if late.last.RealDeltaTime >= 50*time.Millisecond {
+		t.Fatalf("expected the late actor's deltaTime to only cover the time since it was added, got %v", late.last.RealDeltaTime)
+	}
+	if early.last.DeltaTime != early.last.RealDeltaTime {
+		t.Fatalf("expected no time dilation by default, got %v and %v", early.last.DeltaTime, early.last.RealDeltaTime)
+	}
+
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if early.last.Frame != 2 {
+		t.Fatalf("expected frame 2, got %d", early.last.Frame)
+	}
+}
+
+func TestTickAccumulateSkippedTime(t *testing.T) {
+	m := startTestManager(t)
+	ctx := context.Background()
+
+	dropping := newTickContextActorTest()
+	accumulating := newTickContextActorTest()
+	if err := m.AddActors(
+		actor.ActorSpec{Actor: dropping, Options: []actor.Option{actor.TickEveryFrame()}},
+		actor.ActorSpec{Actor: accumulating, Options: []actor.Option{actor.TickEveryFrame(), actor.AccumulateSkippedTime()}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	dropping.want.Store(false)
+	accumulating.want.Store(false)
+	time.Sleep(50 * time.Millisecond)
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	dropping.want.Store(true)
+	accumulating.want.Store(true)
+	time.Sleep(10 * time.Millisecond)
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if dropping.n != 2 || accumulating.n != 2 {
+		t.Fatalf("expected 2 ticks each, got %d and %d", dropping.n, accumulating.n)
+	}
+	if dropping.last.RealDeltaTime >= 50*time.Millisecond {
+		t.Fatalf("expected the skipped time to be dropped, got %v", dropping.last.RealDeltaTime)
+	}
+	if accumulating.last.RealDeltaTime < 60*time.Millisecond {
+		t.Fatalf("expected the skipped time to be accumulated, got %v", accumulating.last.RealDeltaTime)
+	}
+}
+
+func TestTimeDilation(t *testing.T) {
+	m := startTestManager(t)
+	ctx := context.Background()
+
+	if err := m.SetTimeDilation(-1); !errors.Is(err, actor.ErrInvalidTimeDilation) {
+		t.Fatalf("expected ErrInvalidTimeDilation, got %v", err)
+	}
+	if err := m.SetTimeDilation(0.5); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.TimeDilation(); got != 0.5 {
+		t.Fatalf("expected a time dilation of 0.5, got %v", got)
+	}
+
+	a := newTickContextActorTest()
+	if err := m.AddActor(a, actor.TickEveryFrame()); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if err := m.TickFrameSync(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.last.DeltaTime != a.last.RealDeltaTime/2 {
+		t.Fatalf("expected a dilated deltaTime of %v, got %v", a.last.RealDeltaTime/2, a.last.DeltaTime)
+	}
+}
@@ -0,0 +1,53 @@
+package actor
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	messageTimerPending = int32(iota)
+	messageTimerFired
+	messageTimerCancelled
+)
+
+// TellAfter sends a message to an actor in the manager (see: Tell()) once the duration provided has passed on the
+// manager's clock - if the actor is gone by then, the message is handed to the dead letter observers as a DeadLetterTimer
+// the function returned cancels the message, reporting if it did so before the message was sent
+func (m *Manager) TellAfter(d time.Duration, a Actor, msg Message) func() bool {
+	clock := m.currentClock()
+
+	env := envelope{
+		msg:  msg,
+		kind: DeadLetterTimer,
+	}
+
+	var state atomic.Int32
+	stopCh := make(chan struct{})
+	t := clock.NewTimer(d)
+	done := m.context().Done()
+
+	go func() {
+		select {
+		case <-t.C():
+			if state.CompareAndSwap(messageTimerPending, messageTimerFired) {
+				_ = m.tell(a, env)
+			}
+		case <-done:
+			if state.CompareAndSwap(messageTimerPending, messageTimerFired) {
+				t.Stop()
+				m.undeliverable(a, []envelope{env}, ErrManagerStopped)
+			}
+		case <-stopCh:
+		}
+	}()
+
+	return func() bool {
+		if !state.CompareAndSwap(messageTimerPending, messageTimerCancelled) {
+			return false
+		}
+		t.Stop()
+		close(stopCh)
+		return true
+	}
+}
@@ -0,0 +1,120 @@
+package actor
+
+import (
+	"context"
+	"reflect"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync/atomic"
+)
+
+// Tracer starts spans around actor lifecycle calls and ticks (e.g.: to export them via OpenTelemetry)
+type Tracer interface {
+	// StartSpan starts a span for the operation on the actor provided
+	// the actor is nil for operations that are not specific to an actor, such as a tick group's tick
+	StartSpan(ctx context.Context, operation string, a Actor) (context.Context, Span)
+}
+
+// Span is a single traced operation started by a Tracer
+type Span interface {
+	End(err error)
+}
+
+type tracingSettings struct {
+	tracer         Tracer
+	profilerLabels bool
+}
+
+var tracing atomic.Value
+
+func init() {
+	tracing.Store(&tracingSettings{})
+}
+
+func loadTracing() *tracingSettings {
+	return tracing.Load().(*tracingSettings)
+}
+
+// SetTracer sets the tracer that is notified of every lifecycle call and tick, or clears it if nil
+func SetTracer(t Tracer) {
+	ts := *loadTracing()
+	ts.tracer = t
+	tracing.Store(&ts)
+}
+
+// SetProfilerLabels enables or disables pprof labels carrying the actor's type and name during lifecycle calls and ticks
+func SetProfilerLabels(enabled bool) {
+	ts := *loadTracing()
+	ts.profilerLabels = enabled
+	tracing.Store(&ts)
+}
+
+// tracingActive reports if any form of tracing is enabled, so the callers can skip the setup costs when not
+func tracingActive() bool {
+	ts := loadTracing()
+	return ts.tracer != nil || ts.profilerLabels || trace.IsEnabled()
+}
+
+type actorNameKey struct{}
+
+// withActorName attaches an actor's name to the context, for use in traces
+func withActorName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorNameKey{}, name)
+}
+
+// traceCall runs fn within a span, a runtime/trace region, and pprof labels - each only if enabled
+func traceCall(ctx context.Context, operation string, a Actor, fn func() error) error {
+	if !tracingActive() {
+		return fn()
+	}
+
+	return traceCallCtx(ctx, operation, a, func(context.Context) error {
+		return fn()
+	})
+}
+
+// traceCallCtx is traceCall for context-aware calls - fn receives the context carrying the span and labels
+func traceCallCtx(ctx context.Context, operation string, a Actor, fn func(ctx context.Context) error) error {
+	if !tracingActive() {
+		return fn(ctx)
+	}
+
+	ts := loadTracing()
+
+	var span Span
+	if ts.tracer != nil {
+		ctx, span = ts.tracer.StartSpan(ctx, operation, a)
+	}
+
+	var err error
+	call := func(ctx context.Context) {
+		if trace.IsEnabled() {
+			trace.WithRegion(ctx, operation, func() {
+				err = fn(ctx)
+			})
+		} else {
+			err = fn(ctx)
+		}
+	}
+
+	if ts.profilerLabels {
+		labels := []string{"actor_operation", operation}
+		if a != nil {
+			labels = append(labels, "actor_type", reflect.TypeOf(a).String())
+		}
+		if name, ok := ctx.Value(actorNameKey{}).(string); ok {
+			labels = append(labels, "actor_name", name)
+		}
+		pprof.Do(ctx, pprof.Labels(labels...), call)
+	} else {
+		call(ctx)
+	}
+
+	if span != nil {
+		span.End(err)
+	}
+	return err
+}
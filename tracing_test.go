@@ -0,0 +1,128 @@
+package actor_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heucuva/actor"
+)
+
+type tracerSpanKey struct{}
+
+type tracerTest struct {
+	mu    sync.Mutex
+	spans []tracerTestSpan
+}
+
+type tracerTestSpan struct {
+	operation string
+	a         actor.Actor
+	parent    string
+	ended     bool
+}
+
+type tracerTestSpanRef struct {
+	t *tracerTest
+	i int
+}
+
+func (t *tracerTest) StartSpan(ctx context.Context, operation string, a actor.Actor) (context.Context, actor.Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	parent, _ := ctx.Value(tracerSpanKey{}).(string)
+	t.spans = append(t.spans, tracerTestSpan{
+		operation: operation,
+		a:         a,
+		parent:    parent,
+	})
+	return context.WithValue(ctx, tracerSpanKey{}, operation), tracerTestSpanRef{t: t, i: len(t.spans) - 1}
+}
+
+func (s tracerTestSpanRef) End(err error) {
+	s.t.mu.Lock()
+	defer s.t.mu.Unlock()
+	s.t.spans[s.i].ended = true
+}
+
+func (t *tracerTest) find(operation string, a actor.Actor) (tracerTestSpan, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, s := range t.spans {
+		if s.operation == operation && s.a == a {
+			return s, true
+		}
+	}
+	return tracerTestSpan{}, false
+}
+
+type tracingActorTest struct {
+	spawnActorTest
+}
+
+func (a *tracingActorTest) BeginPlay() error {
+	return nil
+}
+
+func (a *tracingActorTest) Tick(deltaTime time.Duration) error {
+	return nil
+}
+
+func (a *tracingActorTest) EndPlay(endPlayReason error) error {
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	tracer := &tracerTest{}
+	actor.SetTracer(tracer)
+	actor.SetProfilerLabels(true)
+	defer func() {
+		actor.SetTracer(nil)
+		actor.SetProfilerLabels(false)
+	}()
+
+	m := startTestManager(t)
+
+	a, err := actor.SpawnActor(reflect.TypeOf(tracingActorTest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.AddActor(a, actor.TickInterval(time.Millisecond), actor.Name("traced")); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, found := tracer.find("Tick", a); found {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Tick not traced")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := m.RemoveActor(a, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, op := range []string{"PostSpawnInitialize", "ExecuteConstruction", "OnConstruction", "PostActorConstruction", "PreInitializeComponents", "InitializeComponents", "OnActorSpawned", "BeginPlay", "EndPlay"} {
+		s, found := tracer.find(op, a)
+		if !found {
+			t.Fatalf("%s not traced", op)
+		}
+		if !s.ended {
+			t.Fatalf("%s span not ended", op)
+		}
+	}
+
+	s, _ := tracer.find("Tick", a)
+	if s.parent != "TickGroup" {
+		t.Fatalf("expected Tick span to be a child of the TickGroup span, got %q", s.parent)
+	}
+}
@@ -1,92 +1,239 @@
 package actor
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
-// PostSpawnInitialize calls an actor's PostSpawnInitialize() function, if it has one
+// PostSpawnInitialize calls an actor's PostSpawnInitializeCtx() or PostSpawnInitialize() function, if it has one
 func PostSpawnInitialize(a Actor) error {
+	return postSpawnInitialize(context.Background(), a, 0)
+}
+
+func postSpawnInitialize(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(PostSpawnInitializeCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "PostSpawnInitialize", a, t.PostSpawnInitializeCtx)
+	}
+
 	if t, ok := a.(PostSpawnInitializeIntf); ok {
-		return t.PostSpawnInitialize()
+		return traceCall(ctx, "PostSpawnInitialize", a, t.PostSpawnInitialize)
 	}
 
 	return nil
 }
 
-// ExecuteConstruction calls an actor's ExecuteConstruction() function, if it has one
+// ExecuteConstruction calls an actor's ExecuteConstructionCtx() or ExecuteConstruction() function, if it has one
 func ExecuteConstruction(a Actor) error {
+	return executeConstruction(context.Background(), a, 0)
+}
+
+func executeConstruction(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(ExecuteConstructionCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "ExecuteConstruction", a, t.ExecuteConstructionCtx)
+	}
+
 	if t, ok := a.(ExecuteConstructionIntf); ok {
-		return t.ExecuteConstruction()
+		return traceCall(ctx, "ExecuteConstruction", a, t.ExecuteConstruction)
 	}
 
 	return nil
 }
 
-// OnConstruction calls an actor's OnConstruction() function, if it has one
+// OnConstruction calls an actor's OnConstructionCtx() or OnConstruction() function, if it has one
 func OnConstruction(a Actor) error {
+	return onConstruction(context.Background(), a, 0)
+}
+
+func onConstruction(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(OnConstructionCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "OnConstruction", a, t.OnConstructionCtx)
+	}
+
 	if t, ok := a.(OnConstructionIntf); ok {
-		return t.OnConstruction()
+		return traceCall(ctx, "OnConstruction", a, t.OnConstruction)
 	}
 
 	return nil
 }
 
-// PostActorConstruction calls an actor's PostActorConstruction() function, if it has one
+// PostActorConstruction calls an actor's PostActorConstructionCtx() or PostActorConstruction() function, if it has one
 func PostActorConstruction(a Actor) error {
+	return postActorConstruction(context.Background(), a, 0)
+}
+
+func postActorConstruction(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(PostActorConstructionCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "PostActorConstruction", a, t.PostActorConstructionCtx)
+	}
+
 	if t, ok := a.(PostActorConstructionIntf); ok {
-		return t.PostActorConstruction()
+		return traceCall(ctx, "PostActorConstruction", a, t.PostActorConstruction)
 	}
 
 	return nil
 }
 
-// PreInitializeComponents calls an actor's PreInitializeComponents() function, if it has one
+// PreInitializeComponents calls an actor's PreInitializeComponentsCtx() or PreInitializeComponents() function, if it has one
 func PreInitializeComponents(a Actor) error {
+	return preInitializeComponents(context.Background(), a, 0)
+}
+
+func preInitializeComponents(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(PreInitializeComponentsCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "PreInitializeComponents", a, t.PreInitializeComponentsCtx)
+	}
+
 	if t, ok := a.(PreInitializeComponentsIntf); ok {
-		return t.PreInitializeComponents()
+		return traceCall(ctx, "PreInitializeComponents", a, t.PreInitializeComponents)
 	}
 
 	return nil
 }
 
-// InitializeComponents calls an actor's InitializeComponents() function, if it has one
+// InitializeComponents calls an actor's InitializeComponentsCtx() or InitializeComponents() function, if it has one
 func InitializeComponents(a Actor) error {
+	return initializeComponents(context.Background(), a, 0)
+}
+
+func initializeComponents(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(InitializeComponentsCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "InitializeComponents", a, t.InitializeComponentsCtx)
+	}
+
 	if t, ok := a.(InitializeComponentsIntf); ok {
-		return t.InitializeComponents()
+		return traceCall(ctx, "InitializeComponents", a, t.InitializeComponents)
 	}
 
 	return nil
 }
 
-// PostInitializeComponents calls an actor's PostInitializeComponents() function, if it has one
+// PostInitializeComponents calls an actor's PostInitializeComponentsCtx() or PostInitializeComponents() function, if it has one
 func PostInitializeComponents(a Actor) error {
+	return postInitializeComponents(context.Background(), a, 0)
+}
+
+func postInitializeComponents(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(PostInitializeComponentsCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "PostInitializeComponents", a, t.PostInitializeComponentsCtx)
+	}
+
 	if t, ok := a.(PostInitializeComponentsIntf); ok {
-		return t.PostInitializeComponents()
+		return traceCall(ctx, "PostInitializeComponents", a, t.PostInitializeComponents)
 	}
 
 	return nil
 }
 
-// OnActorSpawned calls an actor's OnActorSpawned() function, if it has one
+// OnActorSpawned calls an actor's OnActorSpawnedCtx() or OnActorSpawned() function, if it has one
 func OnActorSpawned(a Actor) error {
+	return onActorSpawned(context.Background(), a, 0)
+}
+
+func onActorSpawned(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(OnActorSpawnedCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "OnActorSpawned", a, t.OnActorSpawnedCtx)
+	}
+
 	if t, ok := a.(OnActorSpawnedIntf); ok {
-		return t.OnActorSpawned()
+		return traceCall(ctx, "OnActorSpawned", a, t.OnActorSpawned)
 	}
 
 	return nil
 }
 
-// BeginPlay calls an actor's BeginPlay() function, if it has one
+// BeginPlay calls an actor's BeginPlayCtx() or BeginPlay() function, if it has one
 func BeginPlay(a Actor) error {
+	return beginPlay(context.Background(), a, 0)
+}
+
+func beginPlay(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(BeginPlayCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "BeginPlay", a, t.BeginPlayCtx)
+	}
+
 	if t, ok := a.(BeginPlayIntf); ok {
-		return t.BeginPlay()
+		return traceCall(ctx, "BeginPlay", a, t.BeginPlay)
 	}
 
 	return nil
 }
 
-// Tick calls an actor's Tick() function, if it has one
+// Tick calls an actor's TickWithContext(), TickCtx() or Tick() function, if it has one
+// actors that have ended play (or are in the middle of doing so) may not be ticked
 func Tick(a Actor, deltaTime time.Duration) error {
+	return TickWithContext(a, TickContext{
+		DeltaTime:     deltaTime,
+		RealDeltaTime: deltaTime,
+	})
+}
+
+// TickWithContext calls an actor's TickWithContext() function, if it has one, otherwise its TickCtx() or Tick()
+// function with the context's DeltaTime
+// actors that have ended play (or are in the middle of doing so) may not be ticked
+func TickWithContext(a Actor, tc TickContext) error {
+	if l, found := lookupLifecycle(a); found {
+		switch state := l.load(); state {
+		case StateEndingPlay, StatePendingKill, StateDestroyed:
+			return &LifecycleError{
+				Op:    "Tick",
+				State: state,
+			}
+		}
+	}
+
+	return tick(context.Background(), a, tc, 0)
+}
+
+func tick(ctx context.Context, a Actor, tc TickContext, timeout time.Duration) error {
+	if t, ok := a.(TickWithContextIntf); ok {
+		if !tracingActive() {
+			return t.TickWithContext(tc)
+		}
+
+		return traceCall(ctx, "Tick", a, func() error {
+			return t.TickWithContext(tc)
+		})
+	}
+
+	if t, ok := a.(TickCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		if !tracingActive() {
+			return t.TickCtx(ctx, tc.DeltaTime)
+		}
+
+		return traceCallCtx(ctx, "Tick", a, func(ctx context.Context) error {
+			return t.TickCtx(ctx, tc.DeltaTime)
+		})
+	}
+
 	if t, ok := a.(TickIntf); ok {
-		return t.Tick(deltaTime)
+		if !tracingActive() {
+			// fast path, as this is called very frequently
+			return t.Tick(tc.DeltaTime)
+		}
+
+		return traceCall(ctx, "Tick", a, func() error {
+			return t.Tick(tc.DeltaTime)
+		})
 	}
 
 	return nil
@@ -101,28 +248,126 @@ func WantTick(a Actor) (bool, error) {
 	return true, nil
 }
 
-// EndPlay calls an actor's EndPlay() function, if it has one
+// Significance calls an actor's Significance() function, if it has one
+func Significance(a Actor) (float64, error) {
+	if t, ok := a.(SignificanceIntf); ok {
+		return t.Significance()
+	}
+
+	return 0, nil
+}
+
+// Receive calls an actor's Receive() function, if it has one
+func Receive(a Actor, msg Message) error {
+	if t, ok := a.(ReceiveIntf); ok {
+		return t.Receive(msg)
+	}
+
+	return nil
+}
+
+// Respond calls an actor's Respond() function, if it has one
+func Respond(a Actor, msg Message) (Message, error) {
+	if t, ok := a.(RespondIntf); ok {
+		return t.Respond(msg)
+	}
+
+	return nil, ErrActorCannotRespond
+}
+
+// NetUpdateFrequency calls an actor's NetUpdateFrequency() function, if it has one
+func NetUpdateFrequency(a Actor) float64 {
+	if t, ok := a.(NetUpdateFrequencyIntf); ok {
+		return t.NetUpdateFrequency()
+	}
+
+	return 0
+}
+
+// OnReplicated calls an actor's OnReplicated() function, if it has one
+func OnReplicated(a Actor, fields []string) error {
+	if t, ok := a.(OnReplicatedIntf); ok {
+		return t.OnReplicated(fields)
+	}
+
+	return nil
+}
+
+// EndPlay calls an actor's EndPlayCtx() or EndPlay() function, if it has one
 func EndPlay(a Actor, endPlayReason error) error {
+	return endPlay(context.Background(), a, endPlayReason, 0)
+}
+
+func endPlay(ctx context.Context, a Actor, endPlayReason error, timeout time.Duration) error {
+	if t, ok := a.(EndPlayCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "EndPlay", a, func(ctx context.Context) error {
+			return t.EndPlayCtx(ctx, endPlayReason)
+		})
+	}
+
 	if t, ok := a.(EndPlayIntf); ok {
-		return t.EndPlay(endPlayReason)
+		return traceCall(ctx, "EndPlay", a, func() error {
+			return t.EndPlay(endPlayReason)
+		})
 	}
 
 	return nil
 }
 
-// BeginDestroy calls an actor's BeginDestroy() function, if it has one
+// BeginDestroy calls an actor's BeginDestroyCtx() or BeginDestroy() function, if it has one
 func BeginDestroy(a Actor) error {
+	return beginDestroy(context.Background(), a, 0)
+}
+
+func beginDestroy(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(BeginDestroyCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "BeginDestroy", a, t.BeginDestroyCtx)
+	}
+
 	if t, ok := a.(BeginDestroyIntf); ok {
-		return t.BeginDestroy()
+		return traceCall(ctx, "BeginDestroy", a, t.BeginDestroy)
 	}
 
 	return nil
 }
 
-// FinishDestroy calls an actor's FinishDestroy() function, if it has one
+// FinishDestroy calls an actor's FinishDestroyCtx() or FinishDestroy() function, if it has one
 func FinishDestroy(a Actor) error {
+	return finishDestroy(context.Background(), a, 0)
+}
+
+func finishDestroy(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(FinishDestroyCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "FinishDestroy", a, t.FinishDestroyCtx)
+	}
+
 	if t, ok := a.(FinishDestroyIntf); ok {
-		return t.FinishDestroy()
+		return traceCall(ctx, "FinishDestroy", a, t.FinishDestroy)
+	}
+
+	return nil
+}
+
+// Reset calls an actor's ResetCtx() or Reset() function, if it has one
+func Reset(a Actor) error {
+	return reset(context.Background(), a, 0)
+}
+
+func reset(ctx context.Context, a Actor, timeout time.Duration) error {
+	if t, ok := a.(ResetCtxIntf); ok {
+		ctx, cancel := withCallTimeout(ctx, timeout)
+		defer cancel()
+		return traceCallCtx(ctx, "Reset", a, t.ResetCtx)
+	}
+
+	if t, ok := a.(ResetIntf); ok {
+		return traceCall(ctx, "Reset", a, t.Reset)
 	}
 
 	return nil